@@ -0,0 +1,414 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/config"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/util"
+)
+
+// Request/Response types matching API DTOs
+
+// ApiKeyResponse represents an API key (the secret itself is never returned)
+type ApiKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"keyPrefix,omitempty"`
+	Active     bool       `json:"active"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// ApiKeyCreateRequest represents a request to create an API key
+type ApiKeyCreateRequest struct {
+	Name      string     `json:"name"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// ApiKeyCreateResponse represents a newly issued API key
+// Key is only returned when the key is created or rotated.
+type ApiKeyCreateResponse struct {
+	ApiKeyResponse
+	Key string `json:"key"`
+}
+
+// apikeyCmd represents the apikey command
+var apikeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "API key management commands",
+	Long: `Manage the API keys of the authenticated user.
+
+Available commands:
+  list   - List API keys
+  create - Create a new API key
+  revoke - Revoke an API key
+  rotate - Replace an API key with a new one`,
+}
+
+// apikeyListCmd represents the apikey list command
+var apikeyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys",
+	Long: `List the API keys of the authenticated user.
+
+Examples:
+  cloud-storage-api-cli apikey list
+  cloud-storage-api-cli apikey list -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		var keys []ApiKeyResponse
+		if err := apiClient.GetContext(cmd.Context(), "/api/api-keys", &keys); err != nil {
+			return fmt.Errorf("failed to list API keys: %w", err)
+		}
+
+		return printResult(&util.Result{
+			Data:  keys,
+			Table: apiKeyTable(keys),
+			Text:  func() { displayApiKeyList(keys) },
+		})
+	},
+}
+
+// apikeyCreateCmd represents the apikey create command
+var apikeyCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new API key",
+	Long: `Create a new API key.
+
+The key is shown only once. Use --save to store it in the active profile
+instead of copying it by hand.
+
+Expiry can be given as a duration from now (--expires-in, e.g. 90d, 2w, 36h)
+or as a date (--expires-at, e.g. 2025-12-31 or 2025-12-31T23:59:59Z).
+Without either, the key does not expire.
+
+Examples:
+  cloud-storage-api-cli apikey create --name ci
+  cloud-storage-api-cli apikey create --name ci --expires-in 90d
+  cloud-storage-api-cli apikey create --name laptop --expires-at 2025-12-31 --save`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		expiresIn, _ := cmd.Flags().GetString("expires-in")
+		expiresAtStr, _ := cmd.Flags().GetString("expires-at")
+		save, _ := cmd.Flags().GetBool("save")
+
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return fmt.Errorf("--name is required")
+		}
+
+		expiresAt, err := parseApiKeyExpiry(expiresIn, expiresAtStr, time.Now())
+		if err != nil {
+			return err
+		}
+
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		req := ApiKeyCreateRequest{Name: name, ExpiresAt: expiresAt}
+		var created ApiKeyCreateResponse
+		if err := apiClient.PostContext(cmd.Context(), "/api/api-keys", req, &created); err != nil {
+			return fmt.Errorf("failed to create API key: %w", err)
+		}
+
+		return finishIssuedApiKey(&created, save, "created")
+	},
+}
+
+// apikeyRevokeCmd represents the apikey revoke command
+var apikeyRevokeCmd = &cobra.Command{
+	Use:   "revoke <key-id>",
+	Short: "Revoke an API key",
+	Long: `Revoke an API key. Requests using the key are rejected afterwards.
+
+You will be prompted for confirmation unless the --force flag is used.
+
+Examples:
+  cloud-storage-api-cli apikey revoke 123e4567-e89b-12d3-a456-426614174000
+  cloud-storage-api-cli apikey revoke 123e4567-e89b-12d3-a456-426614174000 --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keyID := args[0]
+		force, _ := cmd.Flags().GetBool("force")
+
+		if err := util.ValidateUUID(keyID); err != nil {
+			return err
+		}
+
+		// Prompt for confirmation if not forced
+		if !force {
+			fmt.Printf("Are you sure you want to revoke API key '%s'? This cannot be undone. (y/N): ", keyID)
+			var response string
+			fmt.Scanln(&response)
+			response = strings.ToLower(strings.TrimSpace(response))
+			if response != "y" && response != "yes" {
+				fmt.Println("Revoke cancelled.")
+				return nil
+			}
+		}
+
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		if err := apiClient.DeleteContext(cmd.Context(), fmt.Sprintf("/api/api-keys/%s", keyID)); err != nil {
+			return fmt.Errorf("failed to revoke API key: %w", err)
+		}
+
+		return printResult(&util.Result{
+			Data: struct {
+				ID      string `json:"id"`
+				Revoked bool   `json:"revoked"`
+			}{keyID, true},
+			Text: func() { fmt.Printf("API key revoked: %s\n", keyID) },
+		})
+	},
+}
+
+// apikeyRotateCmd represents the apikey rotate command
+var apikeyRotateCmd = &cobra.Command{
+	Use:   "rotate <key-id>",
+	Short: "Replace an API key with a new one",
+	Long: `Rotate an API key. The server issues a new key with the same name and
+expiry and revokes the old one.
+
+Use --save to store the new key in the active profile, so a pipeline can
+rotate the key it authenticates with.
+
+Examples:
+  cloud-storage-api-cli apikey rotate 123e4567-e89b-12d3-a456-426614174000
+  cloud-storage-api-cli apikey rotate 123e4567-e89b-12d3-a456-426614174000 --save
+  cloud-storage-api-cli apikey rotate 123e4567-e89b-12d3-a456-426614174000 -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keyID := args[0]
+		save, _ := cmd.Flags().GetBool("save")
+
+		if err := util.ValidateUUID(keyID); err != nil {
+			return err
+		}
+
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		var rotated ApiKeyCreateResponse
+		if err := apiClient.PostContext(cmd.Context(), fmt.Sprintf("/api/api-keys/%s/rotate", keyID), nil, &rotated); err != nil {
+			return fmt.Errorf("failed to rotate API key: %w", err)
+		}
+
+		return finishIssuedApiKey(&rotated, save, "rotated")
+	},
+}
+
+// parseApiKeyExpiry converts the --expires-in/--expires-at flags into an expiry time
+// It returns nil when neither flag is set.
+func parseApiKeyExpiry(expiresIn, expiresAt string, now time.Time) (*time.Time, error) {
+	if expiresIn != "" && expiresAt != "" {
+		return nil, fmt.Errorf("use either --expires-in or --expires-at, not both")
+	}
+
+	if expiresIn != "" {
+		d, err := util.ParseDuration(expiresIn)
+		if err != nil {
+			return nil, err
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("--expires-in must be positive")
+		}
+		t := now.Add(d).UTC()
+		return &t, nil
+	}
+
+	if expiresAt != "" {
+		t, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			t, err = time.ParseInLocation("2006-01-02", expiresAt, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --expires-at: %s (use YYYY-MM-DD or RFC 3339)", expiresAt)
+			}
+		}
+		if !t.After(now) {
+			return nil, fmt.Errorf("--expires-at must be in the future")
+		}
+		t = t.UTC()
+		return &t, nil
+	}
+
+	return nil, nil
+}
+
+// finishIssuedApiKey displays a newly issued key and optionally saves it
+// The key is always shown before saving: the server never returns it again, and
+// after a rotation the old key no longer works, so a failed save must not lose it.
+func finishIssuedApiKey(issued *ApiKeyCreateResponse, save bool, action string) error {
+	if issued.Key == "" {
+		return fmt.Errorf("server did not return the new API key")
+	}
+
+	err := printResult(&util.Result{
+		Data: issued,
+		Text: func() {
+			fmt.Printf("API key %s successfully!\n", action)
+			fmt.Printf("ID:       %s\n", issued.ID)
+			fmt.Printf("Name:     %s\n", issued.Name)
+			if issued.ExpiresAt != nil {
+				fmt.Printf("Expires:  %s\n", issued.ExpiresAt.Format(time.RFC3339))
+			} else {
+				fmt.Printf("Expires:  never\n")
+			}
+			fmt.Printf("Key:      %s\n", issued.Key)
+			if !save {
+				fmt.Println("\nStore this key now - it will not be shown again.")
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("%w (new API key: %s)", err, issued.Key)
+	}
+	if !save {
+		return nil
+	}
+
+	cfg, err := config.LoadConfig()
+	if err == nil {
+		cfg.APIKey = issued.Key
+		err = config.SaveConfig(cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("API key %s but could not be saved, store the key shown above now: %w", action, err)
+	}
+	if humanOutput() {
+		fmt.Printf("\nAPI key saved to profile: %s\n", cfg.Profile)
+	}
+	return nil
+}
+
+// apiKeyTable returns API keys as a table for the wide and csv output formats
+func apiKeyTable(keys []ApiKeyResponse) *util.Table {
+	table := &util.Table{
+		Columns: []util.Column{
+			{Header: "ID", Width: 36},
+			{Header: "NAME", Width: 20},
+			{Header: "PREFIX", Width: 10},
+			{Header: "ACTIVE", Width: 8},
+			{Header: "CREATED AT", Width: 20},
+			{Header: "EXPIRES AT", Width: 20},
+			{Header: "LAST USED AT", Width: 20, Wide: true},
+		},
+	}
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	for _, k := range keys {
+		table.Rows = append(table.Rows, []string{
+			k.ID,
+			k.Name,
+			k.KeyPrefix,
+			fmt.Sprintf("%t", k.Active),
+			k.CreatedAt.Format(time.RFC3339),
+			formatTime(k.ExpiresAt),
+			formatTime(k.LastUsedAt),
+		})
+	}
+	return table
+}
+
+// displayApiKeyList displays API keys in a formatted table
+func displayApiKeyList(keys []ApiKeyResponse) {
+	if len(keys) == 0 {
+		fmt.Println("No API keys found.")
+		return
+	}
+
+	// Print header
+	fmt.Printf("\nAPI Keys (Total: %d)\n\n", len(keys))
+
+	// Print table header
+	fmt.Printf("%-36s %-20s %-10s %-8s %-20s %-20s\n",
+		"ID", "Name", "Prefix", "Status", "Expires At", "Last Used")
+	fmt.Println(strings.Repeat("-", 119))
+
+	// Print table rows
+	for _, k := range keys {
+		// Truncate name if too long
+		name := k.Name
+		if len(name) > 20 {
+			name = name[:17] + "..."
+		}
+
+		prefix := k.KeyPrefix
+		if prefix == "" {
+			prefix = "-"
+		}
+
+		status := "active"
+		if !k.Active {
+			status = "revoked"
+		} else if k.ExpiresAt != nil && k.ExpiresAt.Before(time.Now()) {
+			status = "expired"
+		}
+
+		expiresAt := "never"
+		if k.ExpiresAt != nil {
+			expiresAt = k.ExpiresAt.Format("2006-01-02 15:04:05")
+		}
+		lastUsed := "-"
+		if k.LastUsedAt != nil {
+			lastUsed = k.LastUsedAt.Format("2006-01-02 15:04:05")
+		}
+
+		fmt.Printf("%-36s %-20s %-10s %-8s %-20s %-20s\n",
+			k.ID, name, prefix, status, expiresAt, lastUsed)
+	}
+
+	fmt.Println(strings.Repeat("-", 119))
+	fmt.Println()
+}
+
+func init() {
+	rootCmd.AddCommand(apikeyCmd)
+	apikeyCmd.AddCommand(apikeyListCmd)
+	apikeyCmd.AddCommand(apikeyCreateCmd)
+	apikeyCmd.AddCommand(apikeyRevokeCmd)
+	apikeyCmd.AddCommand(apikeyRotateCmd)
+
+	apikeyCreateCmd.Flags().String("name", "", "Name of the API key (required)")
+	apikeyCreateCmd.Flags().String("expires-in", "", "Expire the key after this duration (e.g. 90d, 2w, 36h)")
+	apikeyCreateCmd.Flags().String("expires-at", "", "Expire the key at this date (YYYY-MM-DD or RFC 3339)")
+	apikeyCreateCmd.Flags().Bool("save", false, "Save the new key to the active profile")
+	apikeyRevokeCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	apikeyRotateCmd.Flags().Bool("save", false, "Save the new key to the active profile")
+}
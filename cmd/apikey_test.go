@@ -0,0 +1,140 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/client"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/testutil"
+)
+
+const testApiKeyID = "123e4567-e89b-12d3-a456-426614174000"
+
+func TestApiKeyLifecycle_Integration(t *testing.T) {
+	expires := time.Now().Add(90 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	// Setup mock server
+	server := testutil.SetupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/api-keys":
+			testutil.JSONResponse(w, http.StatusOK, []ApiKeyResponse{
+				{ID: testApiKeyID, Name: "ci", KeyPrefix: "csk_ab", Active: true, CreatedAt: time.Now()},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/api-keys":
+			var req ApiKeyCreateRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				testutil.ErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+				return
+			}
+			if req.Name != "ci" || req.ExpiresAt == nil || !req.ExpiresAt.Equal(expires) {
+				testutil.ErrorResponse(w, http.StatusBadRequest, "Unexpected request")
+				return
+			}
+			testutil.JSONResponse(w, http.StatusCreated, ApiKeyCreateResponse{
+				ApiKeyResponse: ApiKeyResponse{ID: testApiKeyID, Name: req.Name, Active: true, ExpiresAt: req.ExpiresAt},
+				Key:            "new-secret-key",
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/api-keys/"+testApiKeyID+"/rotate":
+			testutil.JSONResponse(w, http.StatusOK, ApiKeyCreateResponse{
+				ApiKeyResponse: ApiKeyResponse{ID: "rotated-id", Name: "ci", Active: true},
+				Key:            "rotated-secret-key",
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/api-keys/"+testApiKeyID:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			testutil.ErrorResponse(w, http.StatusNotFound, "Not found")
+		}
+	})
+	defer server.Close()
+
+	apiClient := client.NewClientWithConfig(server.URL, "test-api-key")
+
+	var keys []ApiKeyResponse
+	if err := apiClient.Get("/api/api-keys", &keys); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Name != "ci" {
+		t.Errorf("Unexpected keys: %+v", keys)
+	}
+
+	var created ApiKeyCreateResponse
+	if err := apiClient.Post("/api/api-keys", ApiKeyCreateRequest{Name: "ci", ExpiresAt: &expires}, &created); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if created.Key != "new-secret-key" || created.ID != testApiKeyID {
+		t.Errorf("Unexpected created key: %+v", created)
+	}
+
+	var rotated ApiKeyCreateResponse
+	if err := apiClient.Post("/api/api-keys/"+testApiKeyID+"/rotate", nil, &rotated); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	if rotated.Key != "rotated-secret-key" {
+		t.Errorf("Expected rotated key, got %q", rotated.Key)
+	}
+
+	if err := apiClient.Delete("/api/api-keys/" + testApiKeyID); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+}
+
+func TestParseApiKeyExpiry(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		expiresIn string
+		expiresAt string
+		want      *time.Time
+		wantErr   bool
+	}{
+		{name: "none"},
+		{name: "days", expiresIn: "90d", want: ptrTime(now.Add(90 * 24 * time.Hour))},
+		{name: "hours", expiresIn: "36h", want: ptrTime(now.Add(36 * time.Hour))},
+		{name: "rfc3339", expiresAt: "2025-12-31T23:59:59Z", want: ptrTime(time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC))},
+		{name: "both", expiresIn: "1d", expiresAt: "2025-12-31", wantErr: true},
+		{name: "zero", expiresIn: "0d", wantErr: true},
+		{name: "past", expiresAt: "2020-01-01", wantErr: true},
+		{name: "invalid date", expiresAt: "31/12/2025", wantErr: true},
+		{name: "invalid duration", expiresIn: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseApiKeyExpiry(tt.expiresIn, tt.expiresAt, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseApiKeyExpiry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				if got != nil && !tt.wantErr {
+					t.Errorf("parseApiKeyExpiry() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || !got.Equal(*tt.want) {
+				t.Errorf("parseApiKeyExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -16,7 +16,10 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"syscall"
 	"time"
@@ -54,20 +57,130 @@ var authCmd = &cobra.Command{
 
 Available commands:
   login  - Verify and store API key for authentication
+  logout - Remove the stored API key
   status - Show current authenticated user information`,
 }
 
+// maxAPIKeyInput limits how much is read from --with-key-stdin or --key-file
+const maxAPIKeyInput = 64 * 1024
+
+// LoginResult is the machine-readable output of auth login
+type LoginResult struct {
+	Profile         string       `json:"profile"`
+	CredentialStore string       `json:"credentialStore"`
+	APIURL          string       `json:"apiUrl"`
+	User            UserResponse `json:"user"`
+}
+
 // readPassword securely reads a password from stdin without echoing
+// The prompt goes to stderr so it does not mix with command output.
 func readPassword(prompt string) (string, error) {
-	fmt.Print(prompt)
+	fmt.Fprint(os.Stderr, prompt)
 	passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
-	fmt.Println() // New line after password input
+	fmt.Fprintln(os.Stderr) // New line after password input
 	if err != nil {
 		return "", fmt.Errorf("failed to read password: %w", err)
 	}
 	return string(passwordBytes), nil
 }
 
+// sanitizeAPIKey trims whitespace and removes all control characters from an API key
+func sanitizeAPIKey(apiKey string) string {
+	apiKey = strings.TrimSpace(apiKey)
+	// Remove any control characters (newlines, carriage returns, etc.)
+	return strings.Map(func(r rune) rune {
+		if r >= 32 && r != 127 { // Keep printable ASCII except DEL
+			return r
+		}
+		return -1 // Remove control characters
+	}, apiKey)
+}
+
+// readAPIKeyFrom reads an API key from r, which must contain a single key
+// Surrounding whitespace, including a trailing newline, is ignored.
+func readAPIKeyFrom(r io.Reader, source string) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxAPIKeyInput+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key from %s: %w", source, err)
+	}
+	if len(data) > maxAPIKeyInput {
+		return "", fmt.Errorf("API key from %s is too large", source)
+	}
+	if strings.ContainsAny(strings.TrimSpace(string(data)), "\r\n") {
+		return "", fmt.Errorf("%s must contain a single API key on one line", source)
+	}
+	return string(data), nil
+}
+
+// readAPIKey returns the API key from --with-key-stdin, --key-file, or a terminal prompt
+func readAPIKey(cmd *cobra.Command) (string, error) {
+	withKeyStdin, _ := cmd.Flags().GetBool("with-key-stdin")
+	keyFile, _ := cmd.Flags().GetString("key-file")
+
+	switch {
+	case withKeyStdin && keyFile != "":
+		return "", fmt.Errorf("use either --with-key-stdin or --key-file, not both")
+	case withKeyStdin:
+		return readAPIKeyFrom(cmd.InOrStdin(), "stdin")
+	case keyFile != "":
+		f, err := os.Open(keyFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to open key file: %w", err)
+		}
+		defer f.Close()
+		return readAPIKeyFrom(f, keyFile)
+	}
+
+	if !term.IsTerminal(int(syscall.Stdin)) {
+		return "", errors.New("stdin is not a terminal; use --with-key-stdin or --key-file to log in non-interactively")
+	}
+	// Prompt for API key securely
+	return readPassword("API Key: ")
+}
+
+// promptPassphrase returns the passphrase of the encrypted credential store
+// It reads CLOUD_STORAGE_CREDENTIALS_PASSPHRASE, or prompts on the terminal.
+// The prompt goes to stderr so it does not mix with command output.
+func promptPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(config.PassphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+	if !term.IsTerminal(int(syscall.Stdin)) {
+		return "", fmt.Errorf("no passphrase for the encrypted credential store (set %s)", config.PassphraseEnvVar)
+	}
+
+	return readPassphrase("Credential store passphrase: ", confirm)
+}
+
+// readPassphrase reads a passphrase from the terminal without echoing it
+// With confirm, it is read twice and both entries must match.
+func readPassphrase(prompt string, confirm bool) (string, error) {
+	read := func(prompt string) (string, error) {
+		fmt.Fprint(os.Stderr, prompt)
+		passphraseBytes, err := term.ReadPassword(int(syscall.Stdin))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		return string(passphraseBytes), nil
+	}
+
+	passphrase, err := read(prompt)
+	if err != nil {
+		return "", err
+	}
+	if confirm {
+		again, err := read("Confirm passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", fmt.Errorf("passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
+
 // authLoginCmd represents the auth login command
 var authLoginCmd = &cobra.Command{
 	Use:   "login",
@@ -77,26 +190,52 @@ var authLoginCmd = &cobra.Command{
 The API key will be prompted securely (not visible as you type).
 After verification, the API key will be saved to the configuration file.
 
+For CI and containers, read the key from stdin with --with-key-stdin or
+from a file with --key-file instead. Use --output json for machine-readable output.
+
 You can generate API keys from the web interface at the Settings page.
 
+The key is saved to the active profile. Use --profile to log in to another
+profile; the profile is created if it does not exist yet. When --api-url is
+given, the URL is saved to the profile as well.
+
+Use --credential-store to choose where the key is kept:
+  file       - plaintext in config.yaml (file mode 0600)
+  encrypted  - credentials.enc, encrypted with a passphrase
+               (prompted, or read from CLOUD_STORAGE_CREDENTIALS_PASSPHRASE)
+  keyring    - the OS keyring (Secret Service, macOS Keychain, Windows Credential Manager)
+Without the flag the profile keeps its current store ("file" for new profiles).
+
 Examples:
-  cloud-storage-api-cli auth login`,
+  cloud-storage-api-cli auth login
+
+  # Store a key in the "work" profile
+  cloud-storage-api-cli auth login --profile work
+
+  # Store a key and server URL in the "staging" profile
+  cloud-storage-api-cli auth login --profile staging --api-url https://staging.example.com
+
+  # Keep the key in the OS keyring
+  cloud-storage-api-cli auth login --credential-store keyring
+
+  # Non-interactive login (e.g. GitHub Actions)
+  echo "$API_KEY" | cloud-storage-api-cli auth login --with-key-stdin -o json
+  cloud-storage-api-cli auth login --key-file /run/secrets/api-key`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Prompt for API key securely
-		apiKey, err := readPassword("API Key: ")
+		credentialStore, _ := cmd.Flags().GetString("credential-store")
+		if credentialStore != "" {
+			if _, err := config.GetCredentialStore(credentialStore); err != nil {
+				return err
+			}
+		}
+
+		apiKey, err := readAPIKey(cmd)
 		if err != nil {
 			return err
 		}
 		// Trim whitespace and remove all control characters from the API key
-		apiKey = strings.TrimSpace(apiKey)
-		// Remove any control characters (newlines, carriage returns, etc.)
-		apiKey = strings.Map(func(r rune) rune {
-			if r >= 32 && r != 127 { // Keep printable ASCII except DEL
-				return r
-			}
-			return -1 // Remove control characters
-		}, apiKey)
+		apiKey = sanitizeAPIKey(apiKey)
 		if apiKey == "" {
 			return fmt.Errorf("API key cannot be empty")
 		}
@@ -109,25 +248,95 @@ Examples:
 
 		// Create API client with the provided API key and base URL
 		apiClient := client.NewClientWithConfig(cfg.APIURL, apiKey)
+		configureClient(apiClient)
 
 		// Verify API key by calling the verify endpoint
 		var userResp UserResponse
-		if err := apiClient.Post("/api/api-keys/verify", nil, &userResp); err != nil {
+		if err := apiClient.PostContext(cmd.Context(), "/api/api-keys/verify", nil, &userResp); err != nil {
 			return fmt.Errorf("API key verification failed: %w", err)
 		}
 
-		// Save API key to config
-		if err := config.SetValue("api-key", apiKey); err != nil {
+		// Save API key to the profile's credential store
+		cfg.APIKey = apiKey
+		if credentialStore != "" {
+			cfg.CredentialStore = credentialStore
+		}
+		if err := config.SaveConfig(cfg); err != nil {
 			return fmt.Errorf("failed to save API key: %w", err)
 		}
+		if cmd.Flags().Changed("api-url") {
+			if err := config.SetProfileAPIURL(cfg.Profile, cfg.APIURL); err != nil {
+				return fmt.Errorf("failed to save API URL: %w", err)
+			}
+		}
 
-		// Display success message
-		fmt.Println("API key verified and saved successfully!")
-		fmt.Printf("User: %s (%s)\n", userResp.Username, userResp.Email)
-		fmt.Printf("User ID: %s\n", userResp.ID)
-		fmt.Println("API key saved to configuration.")
+		return printResult(&util.Result{
+			Data: LoginResult{
+				Profile:         cfg.Profile,
+				CredentialStore: cfg.CredentialStore,
+				APIURL:          cfg.APIURL,
+				User:            userResp,
+			},
+			Text: func() {
+				fmt.Println("API key verified and saved successfully!")
+				fmt.Printf("User: %s (%s)\n", userResp.Username, userResp.Email)
+				fmt.Printf("User ID: %s\n", userResp.ID)
+				fmt.Printf("API key saved to profile: %s\n", cfg.Profile)
+				fmt.Printf("Credential store: %s\n", cfg.CredentialStore)
+			},
+		})
+	},
+}
 
-		return nil
+// authLogoutCmd represents the auth logout command
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the stored API key",
+	Long: `Remove the API key stored for the active profile, from whichever credential
+store holds it. Other profile settings (such as its API URL) are kept.
+
+The key itself stays valid on the server; use 'apikey revoke' to disable it.
+
+Examples:
+  cloud-storage-api-cli auth logout
+  cloud-storage-api-cli auth logout --profile work
+  cloud-storage-api-cli auth logout --all`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+
+		profiles := []string{config.ActiveProfile()}
+		if all {
+			infos, err := config.ListProfiles()
+			if err != nil {
+				return fmt.Errorf("failed to list profiles: %w", err)
+			}
+			profiles = profiles[:0]
+			for _, info := range infos {
+				profiles = append(profiles, info.Name)
+			}
+		}
+
+		for _, profile := range profiles {
+			if err := config.DeleteAPIKey(profile); err != nil {
+				return fmt.Errorf("failed to remove API key for profile %s: %w", profile, err)
+			}
+		}
+
+		if os.Getenv(config.APIKeyEnvVar) != "" {
+			fmt.Fprintf(os.Stderr, "Warning: %s is still set and will be used for authentication\n", config.APIKeyEnvVar)
+		}
+
+		return printResult(&util.Result{
+			Data: struct {
+				Profiles []string `json:"profiles"`
+			}{profiles},
+			Text: func() {
+				for _, profile := range profiles {
+					fmt.Printf("Logged out of profile: %s\n", profile)
+				}
+			},
+		})
 	},
 }
 
@@ -143,40 +352,47 @@ Examples:
   cloud-storage-api-cli auth status`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Create API client (will use stored API key)
-		apiClient, err := client.NewClient()
+		apiClient, err := newAPIClient()
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %w", err)
 		}
 
 		// Call verify endpoint to get user info
 		var userResp UserResponse
-		if err := apiClient.Post("/api/api-keys/verify", nil, &userResp); err != nil {
+		if err := apiClient.PostContext(cmd.Context(), "/api/api-keys/verify", nil, &userResp); err != nil {
 			return fmt.Errorf("failed to get user information: %w", err)
 		}
 
-		// Check if JSON output is requested
-		if jsonOutput {
-			return util.OutputJSON(userResp)
-		}
-
-		// Display user information
-		fmt.Println("Current User Information:")
-		fmt.Println("========================")
-		fmt.Printf("ID:          %s\n", userResp.ID)
-		fmt.Printf("Username:    %s\n", userResp.Username)
-		fmt.Printf("Email:       %s\n", userResp.Email)
-		fmt.Printf("Active:      %v\n", userResp.Active)
-		fmt.Printf("Created At:  %s\n", userResp.CreatedAt.Format(time.RFC3339))
-		if userResp.LastLoginAt != nil {
-			fmt.Printf("Last Login:  %s\n", userResp.LastLoginAt.Format(time.RFC3339))
-		}
-
-		return nil
+		return printResult(&util.Result{
+			Data: userResp,
+			Text: func() {
+				fmt.Println("Current User Information:")
+				fmt.Println("========================")
+				fmt.Printf("ID:          %s\n", userResp.ID)
+				fmt.Printf("Username:    %s\n", userResp.Username)
+				fmt.Printf("Email:       %s\n", userResp.Email)
+				fmt.Printf("Active:      %v\n", userResp.Active)
+				fmt.Printf("Created At:  %s\n", userResp.CreatedAt.Format(time.RFC3339))
+				if userResp.LastLoginAt != nil {
+					fmt.Printf("Last Login:  %s\n", userResp.LastLoginAt.Format(time.RFC3339))
+				}
+			},
+		})
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(authCmd)
 	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
 	authCmd.AddCommand(authStatusCmd)
+
+	authLogoutCmd.Flags().Bool("all", false, "Remove the stored API keys of all profiles")
+
+	authLoginCmd.Flags().Bool("with-key-stdin", false, "Read the API key from stdin")
+	authLoginCmd.Flags().String("key-file", "", "Read the API key from a file")
+	authLoginCmd.Flags().String("credential-store", "", "where to store the API key: file, encrypted or keyring (default: the profile's current store)")
+
+	// Ask for the encrypted credential store passphrase on the terminal
+	config.PassphraseProvider = promptPassphrase
 }
@@ -17,6 +17,7 @@ package cmd
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -148,3 +149,49 @@ func TestAuthLogin_ErrorHandling(t *testing.T) {
 func TestAuthStatus_ErrorHandling(t *testing.T) {
 	runInvalidAPIKeyTest(t)
 }
+
+func TestSanitizeAPIKey(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"abc123", "abc123"},
+		{"  abc123\n", "abc123"},
+		{"abc123\r\n", "abc123"},
+		{"abc\x00123\x7f", "abc123"},
+		{"\t\n", ""},
+	}
+
+	for _, tt := range tests {
+		if got := sanitizeAPIKey(tt.input); got != tt.expected {
+			t.Errorf("sanitizeAPIKey(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestReadAPIKeyFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"single line", "abc123\n", "abc123", false},
+		{"no newline", "abc123", "abc123", false},
+		{"windows newline", "abc123\r\n", "abc123", false},
+		{"multiple lines", "abc123\ndef456\n", "", true},
+		{"too large", strings.Repeat("a", maxAPIKeyInput+1), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := readAPIKeyFrom(strings.NewReader(tt.input), "stdin")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readAPIKeyFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && sanitizeAPIKey(raw) != tt.want {
+				t.Errorf("readAPIKeyFrom() = %q, want %q", sanitizeAPIKey(raw), tt.want)
+			}
+		})
+	}
+}
@@ -16,7 +16,14 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,6 +31,14 @@ import (
 	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/client"
 	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
 	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/util"
+	"golang.org/x/term"
+)
+
+// Exit codes of batch wait and batch watch
+const (
+	exitBatchFailed    = 2
+	exitBatchCancelled = 3
+	exitBatchTimeout   = 4
 )
 
 // batchCmd represents the batch command
@@ -33,7 +48,12 @@ var batchCmd = &cobra.Command{
 	Long: `Manage and monitor batch jobs.
 
 Available commands:
-  status - Get batch job status and progress`,
+  list   - List batch jobs, optionally by status and job type
+  status - Get batch job status and progress
+  watch  - Follow a batch job live until it finishes
+  wait   - Block until a batch job finishes, with an exit code for its status
+  cancel - Cancel a queued or running batch job
+  errors - List the items of a batch job that failed`,
 }
 
 // batchStatusCmd represents the batch status command
@@ -57,59 +77,502 @@ Examples:
 		}
 
 		// Create API client
-		apiClient, err := client.NewClient()
+		apiClient, err := newAPIClient()
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %w", err)
 		}
 
 		// Get batch job status
-		path := fmt.Sprintf("/api/batches/%s/status", batchID)
+		batchResp, err := apiClient.GetBatchStatus(cmd.Context(), batchID)
+		if err != nil {
+			return err
+		}
+
+		return printResult(&util.Result{
+			Data: batchResp,
+			Text: func() { displayBatchStatus(os.Stdout, batchResp) },
+		})
+	},
+}
+
+// batchListCmd represents the batch list command
+var batchListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List batch jobs",
+	Long: `List your batch jobs, most recent first.
+
+Use --status and --job-type to only show some jobs.
+
+Examples:
+  cloud-storage-api-cli batch list
+  cloud-storage-api-cli batch list --status PROCESSING
+  cloud-storage-api-cli batch list --status failed --job-type BULK_DELETE
+  cloud-storage-api-cli batch list --page 1 --size 50`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status, _ := cmd.Flags().GetString("status")
+		jobType, _ := cmd.Flags().GetString("job-type")
+		page, _ := cmd.Flags().GetInt("page")
+		size, _ := cmd.Flags().GetInt("size")
+
+		// Validate pagination parameters
+		if err := util.ValidatePageNumber(page); err != nil {
+			return err
+		}
+		if err := util.ValidatePageSize(size); err != nil {
+			return err
+		}
+
+		// Build query parameters
+		params := url.Values{}
+		if status != "" {
+			status = strings.ToUpper(status)
+			if !isBatchStatus(status) {
+				return fmt.Errorf("invalid status %q (valid: %s)", status, strings.Join(client.BatchStatuses, ", "))
+			}
+			params.Set("status", status)
+		}
+		if jobType != "" {
+			params.Set("jobType", strings.ToUpper(jobType))
+		}
+		params.Set("page", strconv.Itoa(page))
+		params.Set("size", strconv.Itoa(size))
+
+		// Create API client
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		var pageResp file.BatchPageResponse
+		if err := apiClient.GetContext(cmd.Context(), "/api/batches?"+params.Encode(), &pageResp); err != nil {
+			return fmt.Errorf("failed to list batch jobs: %w", err)
+		}
+
+		table := &util.Table{Columns: batchColumns}
+		for i := range pageResp.Content {
+			table.Rows = append(table.Rows, batchRow(&pageResp.Content[i]))
+		}
+		return printResult(&util.Result{
+			Data:  pageResp,
+			Items: pageResp.Content,
+			Table: table,
+		})
+	},
+}
+
+// batchCancelCmd represents the batch cancel command
+var batchCancelCmd = &cobra.Command{
+	Use:   "cancel <batch-id>",
+	Short: "Cancel a batch job",
+	Long: `Cancel a batch job that is still queued or processing.
+
+Items the job has already processed are not rolled back. You will be prompted
+for confirmation unless the --confirm flag is used.
+
+Examples:
+  cloud-storage-api-cli batch cancel 550e8400-e29b-41d4-a716-446655440000
+  cloud-storage-api-cli batch cancel 550e8400-e29b-41d4-a716-446655440000 --confirm`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		batchID := args[0]
+		confirm, _ := cmd.Flags().GetBool("confirm")
+
+		if err := util.ValidateUUID(batchID); err != nil {
+			return fmt.Errorf("invalid batch ID: %w", err)
+		}
+
+		// Create API client
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		// A finished job cannot be cancelled any more
+		current, err := apiClient.GetBatchStatus(cmd.Context(), batchID)
+		if err != nil {
+			return err
+		}
+		if client.IsBatchFinished(current.Status) {
+			return fmt.Errorf("batch job %s has already finished (%s)", batchID, current.Status)
+		}
+
+		// Prompt for confirmation if not already confirmed
+		if !confirm {
+			fmt.Printf("Batch job %s (%s) has processed %d of %d items.\n",
+				batchID, current.JobType, current.ProcessedItems, current.TotalItems)
+			fmt.Print("Are you sure you want to cancel it? (y/N): ")
+			var response string
+			fmt.Scanln(&response)
+			response = strings.ToLower(strings.TrimSpace(response))
+			if response != "y" && response != "yes" {
+				fmt.Println("Cancellation aborted.")
+				return nil
+			}
+		}
+
 		var batchResp file.BatchJobResponse
-		if err := apiClient.Get(path, &batchResp); err != nil {
-			return fmt.Errorf("failed to get batch job status: %w", err)
+		path := fmt.Sprintf("/api/batches/%s/cancel", batchID)
+		if err := apiClient.PostContext(cmd.Context(), path, nil, &batchResp); err != nil {
+			return fmt.Errorf("failed to cancel batch job: %w", err)
+		}
+
+		return printResult(&util.Result{
+			Data: batchResp,
+			Text: func() {
+				fmt.Printf("Batch job %s cancelled.\n", batchID)
+				displayBatchStatus(os.Stdout, &batchResp)
+			},
+		})
+	},
+}
+
+// batchErrorsCmd represents the batch errors command
+var batchErrorsCmd = &cobra.Command{
+	Use:   "errors <batch-id>",
+	Short: "List the failed items of a batch job",
+	Long: `List the individual items behind a batch job's failed item count, with the
+reason each one failed.
+
+Combine it with --query to feed the failed items into a retry, e.g. the file IDs
+with --query '[].fileId' or the paths with --query '[].path'.
+
+Examples:
+  cloud-storage-api-cli batch errors 550e8400-e29b-41d4-a716-446655440000
+  cloud-storage-api-cli batch errors 550e8400-e29b-41d4-a716-446655440000 -o csv
+  cloud-storage-api-cli batch errors 550e8400-e29b-41d4-a716-446655440000 --query '[].fileId'`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		batchID := args[0]
+		if err := util.ValidateUUID(batchID); err != nil {
+			return fmt.Errorf("invalid batch ID: %w", err)
 		}
 
-		// Check if JSON output is requested
-		if jsonOutput {
-			return util.OutputJSON(batchResp)
+		// Create API client
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
 		}
 
-		// Display batch job status
-		displayBatchStatus(&batchResp)
+		batch, err := apiClient.GetBatchStatus(cmd.Context(), batchID)
+		if err != nil {
+			return err
+		}
 
-		return nil
+		failed := client.FailedBatchItems(batch)
+		if failed == nil {
+			failed = []file.BatchItemResult{}
+		}
+		// Older servers only report the count, not the items behind it
+		if len(failed) == 0 && batch.FailedItems > 0 {
+			return fmt.Errorf("batch job %s reports %d failed items but the server did not return item results", batchID, batch.FailedItems)
+		}
+
+		table := &util.Table{
+			Columns: []util.Column{
+				{Header: "ITEM", Width: 36},
+				{Header: "PATH", Width: 40},
+				{Header: "ERROR", Width: 50},
+			},
+		}
+		for _, item := range failed {
+			table.Rows = append(table.Rows, []string{item.ItemID, item.Path, item.ErrorMessage})
+		}
+		result := &util.Result{Data: failed, Items: failed, Table: table}
+		if len(failed) == 0 {
+			result.Text = func() { fmt.Printf("Batch job %s has no failed items.\n", batchID) }
+		}
+		return printResult(result)
+	},
+}
+
+// batchWatchCmd represents the batch watch command
+var batchWatchCmd = &cobra.Command{
+	Use:   "watch <batch-id>",
+	Short: "Follow a batch job until it finishes",
+	Long: `Poll a batch job and re-render its status in place until it is COMPLETED,
+FAILED or CANCELLED.
+
+The job is polled every --interval. While it reports no progress the delay
+doubles on each poll, up to --max-interval, and drops back once it moves again.
+
+With a machine-readable --output format every poll is written as one item, so
+-o ndjson produces one line per status update.
+
+Exit codes: 0 completed, 2 failed, 3 cancelled, 1 any other error.
+
+Examples:
+  cloud-storage-api-cli batch watch 550e8400-e29b-41d4-a716-446655440000
+  cloud-storage-api-cli batch watch 550e8400-e29b-41d4-a716-446655440000 --interval 5s
+  cloud-storage-api-cli batch watch 550e8400-e29b-41d4-a716-446655440000 -o ndjson`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		batchID := args[0]
+		if err := util.ValidateUUID(batchID); err != nil {
+			return fmt.Errorf("invalid batch ID: %w", err)
+		}
+		policy, err := pollPolicyFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		batch, err := watchBatch(cmd.Context(), apiClient, batchID, policy)
+		if err != nil {
+			return err
+		}
+		return batchStatusError(batch)
 	},
 }
 
+// batchWaitCmd represents the batch wait command
+var batchWaitCmd = &cobra.Command{
+	Use:   "wait <batch-id>",
+	Short: "Wait for a batch job to finish",
+	Long: `Block until a batch job is COMPLETED, FAILED or CANCELLED, then print its final
+status. The exit code reflects the outcome, so scripts can wait on server-side jobs.
+
+Polling works as for 'batch watch'. --timeout bounds the total wait; here it
+replaces the global per-request --timeout, which keeps its default.
+
+Exit codes: 0 completed, 2 failed, 3 cancelled, 4 timed out, 1 any other error.
+
+Examples:
+  cloud-storage-api-cli batch wait 550e8400-e29b-41d4-a716-446655440000
+  cloud-storage-api-cli batch wait 550e8400-e29b-41d4-a716-446655440000 --timeout 10m
+  cloud-storage-api-cli batch wait 550e8400-e29b-41d4-a716-446655440000 --timeout 1h -o json`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		batchID := args[0]
+		waitTimeout, _ := cmd.Flags().GetDuration("timeout")
+		if err := util.ValidateUUID(batchID); err != nil {
+			return fmt.Errorf("invalid batch ID: %w", err)
+		}
+		if waitTimeout < 0 {
+			return fmt.Errorf("--timeout cannot be negative")
+		}
+		policy, err := pollPolicyFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		batch, err := waitForBatch(cmd.Context(), apiClient, batchID, policy, waitTimeout)
+		if err != nil {
+			return err
+		}
+
+		err = printResult(&util.Result{
+			Data: batch,
+			Text: func() { displayBatchStatus(os.Stdout, batch) },
+		})
+		if err != nil {
+			return err
+		}
+		return batchStatusError(batch)
+	},
+}
+
+// waitForBatch polls a batch job until it finishes or waitTimeout (0 for none) passes
+func waitForBatch(ctx context.Context, apiClient *client.Client, batchID string, policy client.PollPolicy, waitTimeout time.Duration) (*file.BatchJobResponse, error) {
+	waitCtx := ctx
+	if waitTimeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, waitTimeout)
+		defer cancel()
+	}
+
+	batch, err := apiClient.WaitForBatch(waitCtx, batchID, policy, nil)
+	if err != nil {
+		if ctx.Err() == nil && waitCtx.Err() == context.DeadlineExceeded {
+			status := "unknown"
+			if batch != nil {
+				status = fmt.Sprintf("%s, %d%%", batch.Status, batch.Progress)
+			}
+			return nil, &exitError{
+				code: exitBatchTimeout,
+				err:  fmt.Errorf("timed out after %s waiting for batch job %s (last status: %s)", waitTimeout, batchID, status),
+			}
+		}
+		return nil, err
+	}
+	return batch, nil
+}
+
+// watchBatch polls a batch job until it finishes, rendering every update
+// On a terminal the status block is redrawn in place; otherwise a new block is
+// printed whenever the status changes. Machine-readable formats get one item per poll.
+func watchBatch(ctx context.Context, apiClient *client.Client, batchID string, policy client.PollPolicy) (*file.BatchJobResponse, error) {
+	var onUpdate func(*file.BatchJobResponse)
+	var stream util.ItemStream
+	var streamErr error
+
+	if humanOutput() {
+		interactive := term.IsTerminal(int(os.Stdout.Fd()))
+		started := time.Now()
+		lines := 0
+		var previous string
+		onUpdate = func(batch *file.BatchJobResponse) {
+			var buf bytes.Buffer
+			displayBatchStatus(&buf, batch)
+			status := buf.String()
+			if !interactive && status == previous {
+				return
+			}
+			previous = status
+
+			fmt.Fprintf(&buf, "Watching:        %s (updated %s)\n",
+				formatDuration(time.Since(started)), time.Now().Format("15:04:05"))
+			if interactive && lines > 0 {
+				// Move the cursor back to the top of the previous block and clear it
+				fmt.Printf("\033[%dA\033[J", lines)
+			}
+			os.Stdout.Write(buf.Bytes())
+			lines = strings.Count(buf.String(), "\n")
+		}
+	} else {
+		stream = currentFormatter().Stream(os.Stdout, batchColumns)
+		onUpdate = func(batch *file.BatchJobResponse) {
+			if streamErr == nil {
+				streamErr = stream.Write(batch, batchRow(batch))
+			}
+		}
+	}
+
+	batch, err := apiClient.WaitForBatch(ctx, batchID, policy, onUpdate)
+	if stream != nil {
+		if closeErr := stream.Close(); streamErr == nil {
+			streamErr = closeErr
+		}
+	}
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, fmt.Errorf("stopped watching batch job %s: %w", batchID, err)
+		}
+		return nil, err
+	}
+	if streamErr != nil {
+		return nil, streamErr
+	}
+	return batch, nil
+}
+
+// isBatchStatus reports whether status is one of client.BatchStatuses
+func isBatchStatus(status string) bool {
+	for _, s := range client.BatchStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// batchStatusError maps the final status of a batch job to the command's exit code
+// It returns nil for a completed job.
+func batchStatusError(batch *file.BatchJobResponse) error {
+	switch strings.ToUpper(batch.Status) {
+	case client.BatchFailed:
+		err := fmt.Errorf("batch job %s failed", batch.BatchID)
+		if batch.ErrorMessage != "" {
+			err = fmt.Errorf("batch job %s failed: %s", batch.BatchID, batch.ErrorMessage)
+		}
+		return &exitError{code: exitBatchFailed, err: err}
+	case client.BatchCancelled:
+		return &exitError{code: exitBatchCancelled, err: fmt.Errorf("batch job %s was cancelled", batch.BatchID)}
+	default:
+		return nil
+	}
+}
+
+// addPollFlags adds the polling flags shared by batch watch and batch wait
+func addPollFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration("interval", client.DefaultPollInterval, "Delay between status polls")
+	cmd.Flags().Duration("max-interval", client.DefaultPollMaxInterval, "Longest delay between polls while the job makes no progress")
+}
+
+// pollPolicyFlags reads the flags added by addPollFlags
+func pollPolicyFlags(cmd *cobra.Command) (client.PollPolicy, error) {
+	interval, _ := cmd.Flags().GetDuration("interval")
+	maxInterval, _ := cmd.Flags().GetDuration("max-interval")
+	if interval <= 0 {
+		return client.PollPolicy{}, fmt.Errorf("--interval must be positive")
+	}
+	if maxInterval < interval {
+		return client.PollPolicy{}, fmt.Errorf("--max-interval cannot be shorter than --interval")
+	}
+	return client.PollPolicy{Interval: interval, MaxInterval: maxInterval}, nil
+}
+
+// batchColumns are the columns of a batch job table
+var batchColumns = []util.Column{
+	{Header: "BATCH ID", Width: 36},
+	{Header: "JOB TYPE", Width: 15},
+	{Header: "STATUS", Width: 12},
+	{Header: "PROGRESS", Width: 8},
+	{Header: "ITEMS", Width: 9},
+	{Header: "FAILED", Width: 6},
+	{Header: "STARTED AT", Wide: true},
+}
+
+// batchRow returns the table cells of a batch job
+func batchRow(batch *file.BatchJobResponse) []string {
+	startedAt := ""
+	if batch.StartedAt != nil {
+		startedAt = batch.StartedAt.Format(time.RFC3339)
+	}
+	return []string{
+		batch.BatchID,
+		batch.JobType,
+		batch.Status,
+		strconv.Itoa(batch.Progress) + "%",
+		fmt.Sprintf("%d/%d", batch.ProcessedItems, batch.TotalItems),
+		strconv.Itoa(batch.FailedItems),
+		startedAt,
+	}
+}
+
 // displayBatchStatus displays batch job status in a formatted way
-func displayBatchStatus(batch *file.BatchJobResponse) {
-	fmt.Println("\nBatch Job Status")
-	fmt.Println(strings.Repeat("=", 50))
-	fmt.Printf("Batch ID:        %s\n", batch.BatchID)
-	fmt.Printf("Job Type:        %s\n", batch.JobType)
-	fmt.Printf("Status:          %s\n", formatBatchStatus(batch.Status))
-	fmt.Printf("Progress:        %d%%\n", batch.Progress)
-	fmt.Printf("Total Items:     %d\n", batch.TotalItems)
-	fmt.Printf("Processed Items: %d\n", batch.ProcessedItems)
-	fmt.Printf("Failed Items:    %d\n", batch.FailedItems)
+func displayBatchStatus(w io.Writer, batch *file.BatchJobResponse) {
+	fmt.Fprintln(w, "\nBatch Job Status")
+	fmt.Fprintln(w, strings.Repeat("=", 50))
+	fmt.Fprintf(w, "Batch ID:        %s\n", batch.BatchID)
+	fmt.Fprintf(w, "Job Type:        %s\n", batch.JobType)
+	fmt.Fprintf(w, "Status:          %s\n", formatBatchStatus(batch.Status))
+	fmt.Fprintf(w, "Progress:        %d%%\n", batch.Progress)
+	fmt.Fprintf(w, "Total Items:     %d\n", batch.TotalItems)
+	fmt.Fprintf(w, "Processed Items: %d\n", batch.ProcessedItems)
+	fmt.Fprintf(w, "Failed Items:    %d\n", batch.FailedItems)
+	if batch.FailedItems > 0 && client.IsBatchFinished(batch.Status) {
+		fmt.Fprintf(w, "                 (see 'batch errors %s')\n", batch.BatchID)
+	}
 
 	// Show progress bar
-	displayProgressBar(batch.Progress)
+	displayProgressBar(w, batch.Progress)
 
 	if batch.StartedAt != nil {
-		fmt.Printf("Started At:      %s\n", batch.StartedAt.Format(time.RFC3339))
+		fmt.Fprintf(w, "Started At:      %s\n", batch.StartedAt.Format(time.RFC3339))
 	}
 	if batch.EstimatedCompletion != nil {
-		fmt.Printf("Estimated Completion: %s\n", batch.EstimatedCompletion.Format(time.RFC3339))
+		fmt.Fprintf(w, "Estimated Completion: %s\n", batch.EstimatedCompletion.Format(time.RFC3339))
 		remaining := time.Until(*batch.EstimatedCompletion)
 		if remaining > 0 {
-			fmt.Printf("Time Remaining:  %s\n", formatDuration(remaining))
+			fmt.Fprintf(w, "Time Remaining:  %s\n", formatDuration(remaining))
 		}
 	}
 	if batch.ErrorMessage != "" {
-		fmt.Printf("\nError: %s\n", batch.ErrorMessage)
+		fmt.Fprintf(w, "\nError: %s\n", batch.ErrorMessage)
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
 // formatBatchStatus formats batch status with appropriate styling
@@ -131,7 +594,7 @@ func formatBatchStatus(status string) string {
 }
 
 // displayProgressBar displays a simple text-based progress bar
-func displayProgressBar(progress int) {
+func displayProgressBar(w io.Writer, progress int) {
 	if progress < 0 {
 		progress = 0
 	}
@@ -144,7 +607,7 @@ func displayProgressBar(progress int) {
 	empty := barWidth - filled
 
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", empty)
-	fmt.Printf("Progress Bar:    [%s] %d%%\n", bar, progress)
+	fmt.Fprintf(w, "Progress Bar:    [%s] %d%%\n", bar, progress)
 }
 
 // formatDuration formats a duration in a human-readable way
@@ -170,5 +633,21 @@ func formatDuration(d time.Duration) string {
 
 func init() {
 	rootCmd.AddCommand(batchCmd)
+	batchCmd.AddCommand(batchListCmd)
 	batchCmd.AddCommand(batchStatusCmd)
+	batchCmd.AddCommand(batchWatchCmd)
+	batchCmd.AddCommand(batchWaitCmd)
+	batchCmd.AddCommand(batchCancelCmd)
+	batchCmd.AddCommand(batchErrorsCmd)
+
+	batchListCmd.Flags().String("status", "", "Only jobs with this status (QUEUED, PROCESSING, COMPLETED, FAILED, CANCELLED)")
+	batchListCmd.Flags().String("job-type", "", "Only jobs of this type (e.g., BULK_DELETE)")
+	batchListCmd.Flags().Int("page", 0, "Page number (0-indexed, default: 0)")
+	batchListCmd.Flags().Int("size", 20, "Page size (default: 20, max: 100)")
+
+	batchCancelCmd.Flags().BoolP("confirm", "y", false, "Skip confirmation prompt")
+
+	addPollFlags(batchWatchCmd)
+	addPollFlags(batchWaitCmd)
+	batchWaitCmd.Flags().Duration("timeout", 0, "Give up after waiting this long, exiting with code 4 (0 waits forever)")
 }
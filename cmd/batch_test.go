@@ -0,0 +1,83 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/client"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/testutil"
+)
+
+func TestBatchStatusError(t *testing.T) {
+	tests := []struct {
+		status string
+		code   int
+	}{
+		{client.BatchCompleted, 0},
+		{client.BatchFailed, exitBatchFailed},
+		{client.BatchCancelled, exitBatchCancelled},
+	}
+	for _, tt := range tests {
+		err := batchStatusError(&file.BatchJobResponse{BatchID: "job-1", Status: tt.status})
+		if tt.code == 0 {
+			if err != nil {
+				t.Errorf("%s: expected no error, got %v", tt.status, err)
+			}
+			continue
+		}
+		var exitErr *exitError
+		if !errors.As(err, &exitErr) || exitErr.code != tt.code {
+			t.Errorf("%s: expected exit code %d, got %v", tt.status, tt.code, err)
+		}
+	}
+}
+
+func TestWaitForBatch_Timeout(t *testing.T) {
+	server := testutil.SetupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		testutil.JSONResponse(w, http.StatusOK, file.BatchJobResponse{BatchID: "job-1", Status: client.BatchProcessing, Progress: 40})
+	})
+	defer server.Close()
+	apiClient := client.NewClientWithConfig(server.URL, "test-api-key")
+
+	policy := client.PollPolicy{Interval: 5 * time.Millisecond, MaxInterval: 10 * time.Millisecond}
+	_, err := waitForBatch(context.Background(), apiClient, "job-1", policy, 30*time.Millisecond)
+
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) || exitErr.code != exitBatchTimeout {
+		t.Fatalf("Expected timeout exit error, got %v", err)
+	}
+}
+
+func TestBatchRow(t *testing.T) {
+	row := batchRow(&file.BatchJobResponse{
+		BatchID: "job-1", JobType: "BULK_DELETE", Status: client.BatchProcessing,
+		Progress: 40, ProcessedItems: 2, TotalItems: 5, FailedItems: 1,
+	})
+	want := []string{"job-1", "BULK_DELETE", "PROCESSING", "40%", "2/5", "1", ""}
+	if len(row) != len(batchColumns) || strings.Join(row, "|") != strings.Join(want, "|") {
+		t.Errorf("batchRow() = %v, want %v", row, want)
+	}
+	if !isBatchStatus(client.BatchCancelled) || isBatchStatus("DONE") {
+		t.Error("isBatchStatus() classified statuses incorrectly")
+	}
+}
@@ -0,0 +1,361 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/client"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/util"
+	"golang.org/x/term"
+)
+
+// fileBulkDeleteCmd represents the file bulk-delete command
+var fileBulkDeleteCmd = &cobra.Command{
+	Use:   "bulk-delete [file-id-or-path...]",
+	Short: "Delete many files in one server-side batch job",
+	Long: `Delete many files with a single batch request that the server processes
+asynchronously.
+
+Files are given as IDs, paths or patterns, as arguments, one per line in the
+file named by --from-file, or on stdin ("-" or piped input). IDs are sent as
+they are; paths and patterns are resolved to IDs first.
+
+The files matched by each pattern are listed before you are asked to confirm.
+When the list is read from stdin, the prompt cannot be answered, so --confirm
+is required.
+
+The command prints the batch job ID and status URL. Use --wait to follow the
+job until it finishes; the exit code then reflects its outcome (see 'batch wait').
+
+Examples:
+  cloud-storage-api-cli file bulk-delete 550e8400-e29b-41d4-a716-446655440000 /tmp/old.log
+  cloud-storage-api-cli file bulk-delete '/logs/2023-*.gz' --confirm --wait
+  cloud-storage-api-cli file bulk-delete --from-file ids.txt
+  cat ids.txt | cloud-storage-api-cli file bulk-delete --confirm`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		confirm, _ := cmd.Flags().GetBool("confirm")
+		wait, _ := cmd.Flags().GetBool("wait")
+
+		entries, fromStdin, err := readBulkInput(cmd, args)
+		if err != nil {
+			return err
+		}
+		if fromStdin && !confirm {
+			return fmt.Errorf("--confirm is required when files are read from stdin")
+		}
+
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		fileIDs, matches, err := resolveBulkFileIDs(cmd.Context(), apiClient, entries)
+		if err != nil {
+			return err
+		}
+
+		// Prompt for confirmation if not already confirmed
+		if !confirm {
+			for _, m := range matches {
+				printGlobMatches(m.Pattern, m.Files)
+			}
+			// The prompt goes to stderr so -o json or ndjson output stays clean
+			fmt.Fprintf(os.Stderr, "Are you sure you want to delete %d files? This cannot be undone. (y/N): ", len(fileIDs))
+			var response string
+			fmt.Scanln(&response)
+			response = strings.ToLower(strings.TrimSpace(response))
+			if response != "y" && response != "yes" {
+				fmt.Fprintln(os.Stderr, "Deletion cancelled.")
+				return nil
+			}
+		}
+
+		batch, err := apiClient.SubmitBatchDelete(cmd.Context(), fileIDs)
+		if err != nil {
+			return err
+		}
+		return finishBatchSubmission(cmd.Context(), apiClient, batch, wait)
+	},
+}
+
+// fileBulkMoveCmd represents the file bulk-move command
+var fileBulkMoveCmd = &cobra.Command{
+	Use:   "bulk-move [file-id-or-path...] --folder-path <folder>",
+	Short: "Move many files in one server-side batch job",
+	Long: `Move many files into a folder with a single batch request that the server
+processes asynchronously.
+
+Files are given as for 'file bulk-delete'. Use 'file mv' to rename files or to
+move a few files right away.
+
+Examples:
+  cloud-storage-api-cli file bulk-move '/inbox/*.pdf' --folder-path /documents
+  cloud-storage-api-cli file bulk-move --from-file ids.txt --folder-path /archive --wait`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		folderPath, _ := cmd.Flags().GetString("folder-path")
+		wait, _ := cmd.Flags().GetBool("wait")
+
+		if err := util.ValidatePath(folderPath); err != nil {
+			return fmt.Errorf("invalid folder path: %w", err)
+		}
+
+		entries, _, err := readBulkInput(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		fileIDs, _, err := resolveBulkFileIDs(cmd.Context(), apiClient, entries)
+		if err != nil {
+			return err
+		}
+
+		batch, err := apiClient.SubmitBatchMove(cmd.Context(), fileIDs, folderPath)
+		if err != nil {
+			return err
+		}
+		return finishBatchSubmission(cmd.Context(), apiClient, batch, wait)
+	},
+}
+
+// fileBulkUploadCmd represents the file bulk-upload command
+var fileBulkUploadCmd = &cobra.Command{
+	Use:   "bulk-upload [local-file...]",
+	Short: "Upload many files as one server-side batch job",
+	Long: `Upload many local files in a single request. The server stores them
+asynchronously as a batch job.
+
+Local files are given as arguments, one per line in the file named by
+--from-file, or on stdin ("-" or piped input). Use 'file upload --recursive' to
+upload a directory file by file instead.
+
+Examples:
+  cloud-storage-api-cli file bulk-upload ./scans/*.pdf --folder-path /scans
+  find ./reports -name '*.csv' | cloud-storage-api-cli file bulk-upload --folder-path /reports --wait`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		folderPath, _ := cmd.Flags().GetString("folder-path")
+		wait, _ := cmd.Flags().GetBool("wait")
+
+		if folderPath != "" {
+			if err := util.ValidatePath(folderPath); err != nil {
+				return fmt.Errorf("invalid folder path: %w", err)
+			}
+		}
+
+		paths, _, err := readBulkInput(cmd, args)
+		if err != nil {
+			return err
+		}
+		for _, p := range paths {
+			info, err := os.Stat(p)
+			if err != nil {
+				if os.IsNotExist(err) {
+					return fmt.Errorf("file not found: %s", p)
+				}
+				return fmt.Errorf("failed to access file: %w", err)
+			}
+			if !info.Mode().IsRegular() {
+				return fmt.Errorf("not a regular file: %s", p)
+			}
+		}
+
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		batch, err := apiClient.SubmitBatchUpload(cmd.Context(), paths, folderPath)
+		if err != nil {
+			return fmt.Errorf("bulk upload failed: %w", err)
+		}
+		return finishBatchSubmission(cmd.Context(), apiClient, batch, wait)
+	},
+}
+
+// readBulkInput returns the entries given as arguments, in the --from-file file, or on stdin
+// An argument of "-" or --from-file - reads stdin, as does running without
+// arguments while stdin is not a terminal. Blank lines and lines starting with
+// '#' are skipped.
+func readBulkInput(cmd *cobra.Command, args []string) (entries []string, fromStdin bool, err error) {
+	fromFile, _ := cmd.Flags().GetString("from-file")
+
+	readStdin := fromFile == "-"
+	for _, arg := range args {
+		if arg == "-" {
+			readStdin = true
+			continue
+		}
+		entries = append(entries, arg)
+	}
+	if len(args) == 0 && fromFile == "" && !term.IsTerminal(int(os.Stdin.Fd())) {
+		readStdin = true
+	}
+
+	if fromFile != "" && fromFile != "-" {
+		f, err := os.Open(fromFile)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to open input file: %w", err)
+		}
+		lines, err := readLines(f)
+		f.Close()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read %s: %w", fromFile, err)
+		}
+		entries = append(entries, lines...)
+	}
+	if readStdin {
+		lines, err := readLines(cmd.InOrStdin())
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read stdin: %w", err)
+		}
+		entries = append(entries, lines...)
+	}
+
+	if len(entries) == 0 {
+		return nil, false, fmt.Errorf("no files given: pass them as arguments, with --from-file, or on stdin")
+	}
+	return entries, readStdin, nil
+}
+
+// readLines returns the non-blank, non-comment lines of r, trimmed
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// bulkGlobMatch holds the files matched by a pattern in the bulk input
+type bulkGlobMatch struct {
+	Pattern string
+	Files   []file.FileResponse
+}
+
+// resolveBulkFileIDs turns file IDs, paths and patterns into a list of unique file IDs
+// IDs are used as given, so long ID lists need no lookups. The files matched by
+// each pattern are returned too, so they can be shown before acting on them.
+func resolveBulkFileIDs(ctx context.Context, apiClient *client.Client, entries []string) ([]string, []bulkGlobMatch, error) {
+	var ids []string
+	seen := map[string]bool{}
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var matches []bulkGlobMatch
+	for _, entry := range entries {
+		if client.HasGlob(entry) {
+			files, err := expandFileGlob(ctx, apiClient, entry)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, f := range files {
+				add(f.ID)
+			}
+			matches = append(matches, bulkGlobMatch{Pattern: entry, Files: files})
+			continue
+		}
+		id, err := apiClient.ResolveFileID(ctx, entry)
+		if err != nil {
+			return nil, nil, err
+		}
+		add(id)
+	}
+	return ids, matches, nil
+}
+
+// finishBatchSubmission reports a submitted batch job and, with wait, follows it to the end
+func finishBatchSubmission(ctx context.Context, apiClient *client.Client, batch *file.BatchJobResponse, wait bool) error {
+	if !wait {
+		return printResult(&util.Result{
+			Data: batch,
+			Text: func() {
+				displayBatchSubmitted(batch)
+				fmt.Printf("\nFollow it with: cloud-storage-api-cli batch watch %s\n", batch.BatchID)
+			},
+		})
+	}
+
+	policy := client.DefaultPollPolicy()
+	if humanOutput() {
+		displayBatchSubmitted(batch)
+		final, err := watchBatch(ctx, apiClient, batch.BatchID, policy)
+		if err != nil {
+			return err
+		}
+		return batchStatusError(final)
+	}
+
+	final, err := waitForBatch(ctx, apiClient, batch.BatchID, policy, 0)
+	if err != nil {
+		return err
+	}
+	if err := printResult(&util.Result{Data: final}); err != nil {
+		return err
+	}
+	return batchStatusError(final)
+}
+
+// displayBatchSubmitted prints the identifiers of a newly submitted batch job
+func displayBatchSubmitted(batch *file.BatchJobResponse) {
+	fmt.Println("Batch job submitted!")
+	fmt.Printf("Batch ID: %s\n", batch.BatchID)
+	if batch.JobType != "" {
+		fmt.Printf("Job Type: %s\n", batch.JobType)
+	}
+	fmt.Printf("Items: %d\n", batch.TotalItems)
+	if batch.StatusURL != "" {
+		fmt.Printf("Status URL: %s\n", batch.StatusURL)
+	}
+}
+
+func init() {
+	fileCmd.AddCommand(fileBulkDeleteCmd)
+	fileCmd.AddCommand(fileBulkMoveCmd)
+	fileCmd.AddCommand(fileBulkUploadCmd)
+
+	for _, cmd := range []*cobra.Command{fileBulkDeleteCmd, fileBulkMoveCmd, fileBulkUploadCmd} {
+		cmd.Flags().String("from-file", "", "Read entries from this file, one per line (- for stdin)")
+		cmd.Flags().Bool("wait", false, "Follow the batch job until it finishes")
+	}
+	fileBulkDeleteCmd.Flags().BoolP("confirm", "y", false, "Skip confirmation prompt")
+	fileBulkMoveCmd.Flags().String("folder-path", "", "Folder to move the files into (Unix-style, e.g., /archive)")
+	fileBulkMoveCmd.MarkFlagRequired("folder-path")
+	fileBulkUploadCmd.Flags().String("folder-path", "", "Optional folder path for the uploaded files (Unix-style, e.g., /photos/2024)")
+}
@@ -0,0 +1,58 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadLines(t *testing.T) {
+	input := "# files to remove\n  /tmp/a.log  \n\n550e8400-e29b-41d4-a716-446655440000\n"
+	got, err := readLines(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("readLines() error = %v", err)
+	}
+	want := []string{"/tmp/a.log", "550e8400-e29b-41d4-a716-446655440000"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readLines() = %v, want %v", got, want)
+	}
+}
+
+func TestResolveBulkFileIDs(t *testing.T) {
+	apiClient, _ := setupFolderTreeServer(t)
+	id := "550e8400-e29b-41d4-a716-446655440000"
+
+	// IDs pass through, paths and patterns are resolved, duplicates are dropped
+	got, matches, err := resolveBulkFileIDs(context.Background(), apiClient,
+		[]string{id, "/photos/a.jpg", "/photos/*.jpg", "/photos/2023/b.jpg"})
+	if err != nil {
+		t.Fatalf("resolveBulkFileIDs() error = %v", err)
+	}
+	if want := []string{id, "file-1", "file-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("resolveBulkFileIDs() = %v, want %v", got, want)
+	}
+	// Pattern matches are kept so they can be listed before confirming
+	if len(matches) != 1 || matches[0].Pattern != "/photos/*.jpg" {
+		t.Errorf("Expected the matches of /photos/*.jpg, got %+v", matches)
+	}
+
+	if _, _, err := resolveBulkFileIDs(context.Background(), apiClient, []string{"/photos/missing.jpg"}); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
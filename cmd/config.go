@@ -17,6 +17,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/config"
@@ -31,18 +32,27 @@ var configCmd = &cobra.Command{
 
 Configuration is stored in ~/.cloud-storage-cli/config.yaml
 
+API keys are stored in named profiles. The active profile is selected with the
+--profile flag, the CLOUD_STORAGE_PROFILE environment variable, or
+'config profile use', in that order. Without any of these the "default" profile
+is used.
+
 You can view or get configuration values. API keys can only be set via the
 'auth login' command, which validates the key before saving it.
 
-Note: API URL is configured via the CLOUD_STORAGE_API_URL environment variable
-or the --api-url flag. It cannot be set via config command.
+The API URL is taken from the --api-url flag, the CLOUD_STORAGE_API_URL
+environment variable or the profile's api_url entry, falling back to the URL
+compiled into the binary. A profile's URL is saved by 'auth login --api-url'.
 
 Examples:
   # Show all configuration values
   cloud-storage-api-cli config show
 
   # Get a specific configuration value
-  cloud-storage-api-cli config get api-key`,
+  cloud-storage-api-cli config get api-key
+
+  # List profiles
+  cloud-storage-api-cli config profile list`,
 }
 
 // configShowCmd represents the config show command
@@ -56,29 +66,34 @@ var configShowCmd = &cobra.Command{
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
-		// Check if JSON output is requested
-		if jsonOutput {
-			// For JSON output, create a struct with masked values
-			type ConfigOutput struct {
-				ConfigFile string `json:"configFile"`
-				APIURL     string `json:"apiUrl"`
-				APIKey     string `json:"apiKey"`
-			}
-			output := ConfigOutput{
-				ConfigFile: config.GetConfigPath(),
-				APIURL:     cfg.APIURL,
-				APIKey:     config.MaskValue(cfg.APIKey),
-			}
-			return util.OutputJSON(output)
+		// Structured output carries the same masked values as the text form
+		type ConfigOutput struct {
+			ConfigFile      string `json:"configFile"`
+			Profile         string `json:"profile"`
+			APIURL          string `json:"apiUrl"`
+			APIKey          string `json:"apiKey"`
+			CredentialStore string `json:"credentialStore"`
+		}
+		configOutput := ConfigOutput{
+			ConfigFile:      config.GetConfigPath(),
+			Profile:         cfg.Profile,
+			APIURL:          cfg.APIURL,
+			APIKey:          config.MaskValue(cfg.APIKey),
+			CredentialStore: cfg.CredentialStore,
 		}
 
-		fmt.Println("Configuration:")
-		fmt.Println("==============")
-		fmt.Printf("Config file: %s\n\n", config.GetConfigPath())
-		fmt.Printf("API URL:        %s\n", cfg.APIURL)
-		fmt.Printf("API Key:        %s\n", config.MaskValue(cfg.APIKey))
-
-		return nil
+		return printResult(&util.Result{
+			Data: configOutput,
+			Text: func() {
+				fmt.Println("Configuration:")
+				fmt.Println("==============")
+				fmt.Printf("Config file: %s\n\n", configOutput.ConfigFile)
+				fmt.Printf("Profile:        %s\n", configOutput.Profile)
+				fmt.Printf("API URL:        %s\n", configOutput.APIURL)
+				fmt.Printf("API Key:        %s\n", configOutput.APIKey)
+				fmt.Printf("Key stored in:  %s\n", configOutput.CredentialStore)
+			},
+		})
 	},
 }
 
@@ -90,6 +105,8 @@ var configGetCmd = &cobra.Command{
 
 Supported keys:
   - api-key
+  - api-url
+  - profile
 
 Sensitive values are masked when displayed.`,
 	Args: cobra.ExactArgs(1),
@@ -105,8 +122,151 @@ Sensitive values are masked when displayed.`,
 			value = config.MaskValue(value)
 		}
 
-		fmt.Println(value)
-		return nil
+		return printResult(&util.Result{
+			Data: struct {
+				Key   string `json:"key"`
+				Value string `json:"value"`
+			}{key, value},
+			Text: func() { fmt.Println(value) },
+		})
+	},
+}
+
+// configProfileCmd represents the config profile command
+var configProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage configuration profiles",
+	Long: `Manage named configuration profiles.
+
+Each profile stores its own API key and, optionally, its own API URL. Profiles
+are created by logging in with 'auth login --profile <name>'.
+
+Examples:
+  cloud-storage-api-cli config profile list
+  cloud-storage-api-cli config profile use work
+  cloud-storage-api-cli config profile delete work`,
+}
+
+// configProfileListCmd represents the config profile list command
+var configProfileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configuration profiles",
+	Long:  `List all configuration profiles. The active profile is marked with '*'. API keys are masked.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profiles, err := config.ListProfiles()
+		if err != nil {
+			return fmt.Errorf("failed to list profiles: %w", err)
+		}
+
+		// Never print stored keys in full; keys outside the file store are not read at all
+		for i := range profiles {
+			if profiles[i].CredentialStore == config.StoreFile {
+				profiles[i].APIKey = config.MaskValue(profiles[i].APIKey)
+			} else {
+				profiles[i].APIKey = "(" + profiles[i].CredentialStore + ")"
+			}
+		}
+
+		if len(profiles) == 0 && humanOutput() {
+			fmt.Println("No profiles found. Use 'auth login' to create one.")
+			return nil
+		}
+
+		table := &util.Table{
+			Columns: []util.Column{
+				{Header: "", Width: 2},
+				{Header: "PROFILE", Width: 20},
+				{Header: "API KEY", Width: 15},
+				{Header: "API URL"},
+				{Header: "STORE", Wide: true},
+			},
+		}
+		for _, p := range profiles {
+			marker := ""
+			if p.Active {
+				marker = "*"
+			}
+			apiURL := p.APIURL
+			if apiURL == "" {
+				apiURL = "(default)"
+			}
+			table.Rows = append(table.Rows, []string{marker, p.Name, p.APIKey, apiURL, p.CredentialStore})
+		}
+
+		return printResult(&util.Result{Data: profiles, Table: table})
+	},
+}
+
+// configProfileUseCmd represents the config profile use command
+var configProfileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the current profile",
+	Long: `Set the profile used when neither --profile nor CLOUD_STORAGE_PROFILE is given.
+
+Examples:
+  cloud-storage-api-cli config profile use work`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.UseProfile(args[0]); err != nil {
+			return fmt.Errorf("failed to switch profile: %w", err)
+		}
+
+		name := strings.ToLower(args[0])
+		return printResult(&util.Result{
+			Data: struct {
+				Profile string `json:"profile"`
+			}{name},
+			Text: func() { fmt.Printf("Switched to profile: %s\n", name) },
+		})
+	},
+}
+
+// configProfileDeleteCmd represents the config profile delete command
+var configProfileDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a profile",
+	Long: `Delete a profile and its stored API key.
+
+If the deleted profile is the current profile, the "default" profile becomes
+current again. You will be prompted for confirmation unless the --force flag
+is used.
+
+Examples:
+  cloud-storage-api-cli config profile delete work
+  cloud-storage-api-cli config profile delete work --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+		force, _ := cmd.Flags().GetBool("force")
+
+		if err := config.ValidateProfileName(name); err != nil {
+			return err
+		}
+
+		// Prompt for confirmation if not forced
+		if !force {
+			fmt.Printf("Are you sure you want to delete profile '%s'? (y/N): ", name)
+			var response string
+			fmt.Scanln(&response)
+			response = strings.ToLower(strings.TrimSpace(response))
+			if response != "y" && response != "yes" {
+				fmt.Println("Delete cancelled.")
+				return nil
+			}
+		}
+
+		if err := config.DeleteProfile(name); err != nil {
+			return fmt.Errorf("failed to delete profile: %w", err)
+		}
+
+		return printResult(&util.Result{
+			Data: struct {
+				Profile string `json:"profile"`
+				Deleted bool   `json:"deleted"`
+			}{name, true},
+			Text: func() { fmt.Printf("Profile deleted: %s\n", name) },
+		})
 	},
 }
 
@@ -117,5 +277,11 @@ func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configProfileCmd)
+	configProfileCmd.AddCommand(configProfileListCmd)
+	configProfileCmd.AddCommand(configProfileUseCmd)
+	configProfileCmd.AddCommand(configProfileDeleteCmd)
+
+	configProfileDeleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
 	// configSetCmd removed - API keys can only be set via 'auth login' command
 }
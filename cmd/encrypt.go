@@ -0,0 +1,113 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/client"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/envelope"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+	"golang.org/x/term"
+)
+
+// encryptedMarker is appended to the names of client-side encrypted files in file tables
+const encryptedMarker = " [encrypted]"
+
+// addEncryptionKeyFlag adds the flag that selects a key file for client-side encryption
+func addEncryptionKeyFlag(cmd *cobra.Command) {
+	cmd.Flags().String("encryption-key-file", "", "Key file for client-side encryption (32 bytes, raw, hex or base64; default: ask for a passphrase)")
+}
+
+// encryptionKey returns the key named by --encryption-key-file, or a passphrase
+// key that asks for the passphrase when it is first needed
+func encryptionKey(cmd *cobra.Command) (*envelope.Key, error) {
+	keyFile, _ := cmd.Flags().GetString("encryption-key-file")
+	if keyFile != "" {
+		return envelope.LoadKeyFile(keyFile)
+	}
+	return envelope.NewPassphraseKey(promptEncryptionPassphrase), nil
+}
+
+// configureEncryption gives apiClient the encryption key selected by cmd's flags
+// Downloads only ask for a passphrase once they meet an encrypted file. With
+// encryptUploads, uploads are encrypted and the passphrase is asked for (twice) now.
+func configureEncryption(cmd *cobra.Command, apiClient *client.Client, encryptUploads bool) error {
+	key, err := encryptionKey(cmd)
+	if err != nil {
+		return err
+	}
+	apiClient.Encryption = key
+	if !encryptUploads {
+		return nil
+	}
+	apiClient.EncryptUploads = true
+	return key.Unlock(true)
+}
+
+// promptEncryptionPassphrase reads the encryption passphrase from the environment or the terminal
+func promptEncryptionPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(envelope.PassphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+	if !term.IsTerminal(int(syscall.Stdin)) {
+		return "", fmt.Errorf("no encryption passphrase (set %s or use --encryption-key-file)", envelope.PassphraseEnvVar)
+	}
+	return readPassphrase("Encryption passphrase: ", confirm)
+}
+
+// tableFilename returns the name of f for a file table column of the given
+// width (0 for no limit), marking encrypted files so the marker is never cut off
+func tableFilename(f *file.FileResponse, width int) string {
+	name, marker := f.Filename, ""
+	if envelope.IsEncryptedName(name) {
+		marker = encryptedMarker
+	}
+	if width > 0 && len(name)+len(marker) > width {
+		name = name[:width-len(marker)-3] + "..."
+	}
+	return name + marker
+}
+
+// listedFile is a file in a listing, marked for machine-readable output when it is
+// client-side encrypted, as the file table does with encryptedMarker
+type listedFile struct {
+	file.FileResponse
+	Encrypted bool `json:"encrypted"`
+}
+
+// newListedFile returns f as a listedFile
+func newListedFile(f file.FileResponse) listedFile {
+	return listedFile{FileResponse: f, Encrypted: envelope.IsEncryptedName(f.Filename)}
+}
+
+// listedPage is a page of a file listing whose files carry the encrypted marker
+type listedPage struct {
+	file.PageResponse
+	Content []listedFile `json:"content"`
+}
+
+// newListedPage returns page with its files as listedFiles
+func newListedPage(page file.PageResponse) listedPage {
+	listed := listedPage{PageResponse: page, Content: make([]listedFile, len(page.Content))}
+	for i, f := range page.Content {
+		listed.Content[i] = newListedFile(f)
+	}
+	return listed
+}
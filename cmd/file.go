@@ -16,9 +16,13 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io/fs"
 	"net/url"
 	"os"
+	"path"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -26,6 +30,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/client"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/envelope"
 	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
 	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/util"
 )
@@ -41,7 +46,12 @@ Available commands:
   list     - List files with pagination and filtering
   download - Download a file from cloud storage
   update   - Update file metadata (filename, folder path)
+  mv       - Move or rename files
+  cp       - Copy files
   delete   - Delete a file from cloud storage
+  bulk-delete, bulk-move, bulk-upload
+           - Process many files in one server-side batch job
+  verify   - Check that a local file matches a stored file
   search   - Search files by filename
   info     - Display file storage information`,
 }
@@ -56,15 +66,38 @@ The file will be associated with your authenticated account.
 Use Unix-style paths (forward slashes) for folder paths, e.g., /photos/2024.
 If --filename is not provided, the original filename will be used.
 
+With --recursive, a directory is uploaded file by file. Local subdirectories
+are mapped onto remote folder paths under --folder-path (default: /).
+
+The SHA-256 of the data sent is compared against the size and checksum the
+server reports back. With --checksum, the digest is also shown, and files for
+which the server reported no checksum are read back and compared.
+
+With --encrypt, files are encrypted on this machine with AES-256-GCM before
+they are sent and stored with an ".enc" suffix, so the server only sees
+ciphertext. The key is derived from a passphrase (from $CLOUD_STORAGE_ENCRYPTION_PASSPHRASE,
+or asked for) or read from --encryption-key-file. Keep the passphrase or key
+file safe: encrypted files cannot be recovered without it.
+
 Examples:
   cloud-storage-api-cli file upload ./document.pdf
   cloud-storage-api-cli file upload ./photo.jpg --folder-path /photos/2024
-  cloud-storage-api-cli file upload ./report.pdf --folder-path /documents --filename custom-report.pdf`,
+  cloud-storage-api-cli file upload ./report.pdf --folder-path /documents --filename custom-report.pdf
+  cloud-storage-api-cli file upload ./photos --recursive --folder-path /photos
+  cloud-storage-api-cli file upload ./backup.tar --checksum
+  cloud-storage-api-cli file upload ./contract.pdf --encrypt --folder-path /legal
+  cloud-storage-api-cli file upload ./records --recursive --encrypt --encryption-key-file ~/.config/storage.key`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		filePath := args[0]
 		folderPath, _ := cmd.Flags().GetString("folder-path")
 		filename, _ := cmd.Flags().GetString("filename")
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		checksum, _ := cmd.Flags().GetBool("checksum")
+		encrypt, _ := cmd.Flags().GetBool("encrypt")
+		if cmd.Flags().Changed("encryption-key-file") && !encrypt {
+			return fmt.Errorf("--encryption-key-file requires --encrypt")
+		}
 
 		// Validate folder path if provided
 		if folderPath != "" {
@@ -89,43 +122,294 @@ Examples:
 			return fmt.Errorf("failed to access file: %w", err)
 		}
 
-		// Check if it's a directory
+		// Directories are only accepted with --recursive
 		if fileInfo.IsDir() {
-			return fmt.Errorf("path is a directory, not a file: %s", filePath)
+			if !recursive {
+				return fmt.Errorf("path is a directory, not a file: %s (use --recursive to upload directories)", filePath)
+			}
+			if filename != "" {
+				return fmt.Errorf("--filename cannot be used with --recursive")
+			}
+
+			apiClient, err := newAPIClient()
+			if err != nil {
+				return fmt.Errorf("failed to create API client: %w", err)
+			}
+			if encrypt {
+				if err := configureEncryption(cmd, apiClient, true); err != nil {
+					return err
+				}
+			}
+			return uploadDirectory(cmd.Context(), apiClient, filePath, folderPath, checksum)
 		}
 
 		// Create API client
-		apiClient, err := client.NewClient()
+		apiClient, err := newAPIClient()
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %w", err)
 		}
+		if encrypt {
+			if err := configureEncryption(cmd, apiClient, true); err != nil {
+				return err
+			}
+		}
 
 		// Upload file
 		var fileResp file.FileResponse
-		if err := apiClient.UploadFile("/api/files/upload", filePath, folderPath, filename, &fileResp); err != nil {
+		digest, err := apiClient.UploadFileDigest(cmd.Context(), "/api/files/upload", filePath, folderPath, filename, &fileResp)
+		if err != nil {
 			return fmt.Errorf("upload failed: %w", err)
 		}
 
-		// Check if JSON output is requested
-		if jsonOutput {
-			return util.OutputJSON(fileResp)
+		var data interface{} = fileResp
+		if checksum {
+			if !digest.Verified {
+				if err := apiClient.VerifyRemote(cmd.Context(), &fileResp, digest); err != nil {
+					return fmt.Errorf("uploaded file failed verification: %w", err)
+				}
+			}
+			data = struct {
+				file.FileResponse
+				Transfer *client.TransferDigest `json:"transfer"`
+			}{fileResp, digest}
+		}
+
+		return printResult(&util.Result{
+			Data: data,
+			Text: func() {
+				fmt.Println("File uploaded successfully!")
+				fmt.Printf("File ID: %s\n", fileResp.ID)
+				fmt.Printf("Filename: %s\n", fileResp.Filename)
+				fmt.Printf("Content Type: %s\n", fileResp.ContentType)
+				fmt.Printf("File Size: %s\n", util.FormatFileSize(fileResp.FileSize))
+				if fileResp.FolderPath != nil {
+					fmt.Printf("Folder Path: %s\n", *fileResp.FolderPath)
+				}
+				fmt.Printf("Cloudinary URL: %s\n", fileResp.CloudinaryUrl)
+				fmt.Printf("Cloudinary Secure URL: %s\n", fileResp.CloudinarySecureUrl)
+				fmt.Printf("Created At: %s\n", fileResp.CreatedAt.Format(time.RFC3339))
+				if encrypt {
+					fmt.Println("Encryption: AES-256-GCM (client-side)")
+				}
+				if checksum {
+					fmt.Printf("SHA-256: %s (verified)\n", digest.SHA256)
+				}
+			},
+		})
+	},
+}
+
+// uploadItem is a local file scheduled for upload along with its remote destination
+type uploadItem struct {
+	LocalPath  string
+	FolderPath string
+	Size       int64
+}
+
+// uploadResult is the outcome of uploading a single file during a recursive upload
+type uploadResult struct {
+	LocalPath  string             `json:"localPath"`
+	FolderPath string             `json:"folderPath"`
+	Size       int64              `json:"size"`
+	Success    bool               `json:"success"`
+	Error      string             `json:"error,omitempty"`
+	SHA256     string             `json:"sha256,omitempty"`
+	File       *file.FileResponse `json:"file,omitempty"`
+}
+
+// uploadSummary is the aggregate result document of a recursive upload
+type uploadSummary struct {
+	Source      string         `json:"source"`
+	Destination string         `json:"destination"`
+	TotalFiles  int            `json:"totalFiles"`
+	Uploaded    int            `json:"uploaded"`
+	Failed      int            `json:"failed"`
+	TotalBytes  int64          `json:"totalBytes"`
+	Results     []uploadResult `json:"results"`
+}
+
+// remoteFolderFor maps a directory relative to the upload root onto a remote folder path
+// under destination. An empty destination means the root folder.
+func remoteFolderFor(destination, relDir string) string {
+	relDir = filepath.ToSlash(relDir)
+	if relDir == "." || relDir == "" {
+		return destination
+	}
+	if destination == "" {
+		destination = "/"
+	}
+	return path.Join(destination, relDir)
+}
+
+// collectUploadFiles walks root and returns every regular file with its remote folder path
+// Symlinks and other special files are skipped.
+func collectUploadFiles(root, destination string) ([]uploadItem, error) {
+	var items []uploadItem
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
 		}
 
-		// Display success message
-		fmt.Println("File uploaded successfully!")
-		fmt.Printf("File ID: %s\n", fileResp.ID)
-		fmt.Printf("Filename: %s\n", fileResp.Filename)
-		fmt.Printf("Content Type: %s\n", fileResp.ContentType)
-		fmt.Printf("File Size: %s\n", util.FormatFileSize(fileResp.FileSize))
-		if fileResp.FolderPath != nil {
-			fmt.Printf("Folder Path: %s\n", *fileResp.FolderPath)
+		rel, err := filepath.Rel(root, filepath.Dir(p))
+		if err != nil {
+			return err
 		}
-		fmt.Printf("Cloudinary URL: %s\n", fileResp.CloudinaryUrl)
-		fmt.Printf("Cloudinary Secure URL: %s\n", fileResp.CloudinarySecureUrl)
-		fmt.Printf("Created At: %s\n", fileResp.CreatedAt.Format(time.RFC3339))
 
+		items = append(items, uploadItem{
+			LocalPath:  p,
+			FolderPath: remoteFolderFor(destination, rel),
+			Size:       info.Size(),
+		})
 		return nil
-	},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+	return items, nil
+}
+
+// uploadDirectory uploads every file under root, mirroring its layout under destination
+// Files are uploaded in parallel according to the --concurrency flag. With
+// checksum, every upload is verified against the server and its SHA-256 recorded.
+func uploadDirectory(ctx context.Context, apiClient *client.Client, root, destination string, checksum bool) error {
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+
+	items, err := collectUploadFiles(root, destination)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("no files found in directory: %s", root)
+	}
+
+	summary := uploadSummary{
+		Source:      root,
+		Destination: destination,
+		TotalFiles:  len(items),
+		Results:     make([]uploadResult, len(items)),
+	}
+	if summary.Destination == "" {
+		summary.Destination = "/"
+	}
+
+	// Build one transfer job per file; invalid folder paths are recorded up front
+	var jobs []client.TransferJob
+	var jobIndexes []int
+	for i, item := range items {
+		summary.Results[i] = uploadResult{
+			LocalPath:  item.LocalPath,
+			FolderPath: item.FolderPath,
+			Size:       item.Size,
+		}
+
+		if item.FolderPath != "" {
+			if err := util.ValidatePath(item.FolderPath); err != nil {
+				summary.Results[i].Error = fmt.Sprintf("invalid folder path: %v", err)
+				continue
+			}
+		}
+
+		result := &summary.Results[i]
+		item := item
+		jobs = append(jobs, client.TransferJob{
+			Name: item.LocalPath,
+			Size: apiClient.UploadSize(item.Size),
+			Run: func(ctx context.Context, c *client.Client) error {
+				var fileResp file.FileResponse
+				digest, err := c.UploadFileDigest(ctx, "/api/files/upload", item.LocalPath, item.FolderPath, "", &fileResp)
+				if err != nil {
+					return err
+				}
+				result.File = &fileResp
+				if checksum {
+					if !digest.Verified {
+						if err := c.VerifyRemote(ctx, &fileResp, digest); err != nil {
+							return err
+						}
+					}
+					result.SHA256 = digest.SHA256
+				}
+				return nil
+			},
+		})
+		jobIndexes = append(jobIndexes, i)
+	}
+
+	scheduler := client.NewTransferScheduler(apiClient, concurrency)
+	scheduler.SetDescription("Uploading")
+	for j, res := range scheduler.Run(ctx, jobs) {
+		result := &summary.Results[jobIndexes[j]]
+		if res.Err != nil {
+			result.Error = res.Err.Error()
+		} else {
+			result.Success = true
+		}
+	}
+
+	for i := range summary.Results {
+		result := &summary.Results[i]
+		if result.Success {
+			summary.Uploaded++
+			summary.TotalBytes += result.Size
+		} else {
+			summary.Failed++
+		}
+	}
+
+	err = printResult(&util.Result{
+		Data:  summary,
+		Items: summary.Results,
+		Text: func() {
+			for i := range summary.Results {
+				displayUploadResult(&summary.Results[i])
+			}
+			displayUploadSummary(&summary)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d files failed to upload", summary.Failed, summary.TotalFiles)
+	}
+	return nil
+}
+
+// displayUploadResult prints a single line for one file of a recursive upload
+func displayUploadResult(result *uploadResult) {
+	folder := result.FolderPath
+	if folder == "" {
+		folder = "/"
+	}
+	if result.Success {
+		fmt.Printf("✓ %s -> %s (%s)\n", result.LocalPath, folder, util.FormatFileSize(result.Size))
+		return
+	}
+	fmt.Printf("✗ %s -> %s: %s\n", result.LocalPath, folder, result.Error)
+}
+
+// displayUploadSummary prints the aggregate result of a recursive upload
+func displayUploadSummary(summary *uploadSummary) {
+	fmt.Println()
+	fmt.Println("Upload Summary")
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Source:      %s\n", summary.Source)
+	fmt.Printf("Destination: %s\n", summary.Destination)
+	fmt.Printf("Total Files: %d\n", summary.TotalFiles)
+	fmt.Printf("Uploaded:    %d\n", summary.Uploaded)
+	fmt.Printf("Failed:      %d\n", summary.Failed)
+	fmt.Printf("Total Size:  %s\n", util.FormatFileSize(summary.TotalBytes))
 }
 
 // fileListCmd represents the file list command
@@ -134,12 +418,31 @@ var fileListCmd = &cobra.Command{
 	Short: "List files in cloud storage",
 	Long: `List files in cloud storage with pagination, sorting, and filtering options.
 
+By default a single page is shown. --all walks every page and --limit N stops
+after N files; both stream results as pages arrive.
+
+Size, date and name filters are applied by the CLI, so they always walk every
+page (up to --limit matches). Sizes accept units (512, 10MB, 1.5GB); times
+accept dates (2025-01-31), RFC3339 timestamps or ages such as 7d or 12h.
+
+Files uploaded with --encrypt are marked "[encrypted]" in the table.
+
 Examples:
   cloud-storage-api-cli file list
   cloud-storage-api-cli file list --page 0 --size 50
   cloud-storage-api-cli file list --sort "filename,asc"
   cloud-storage-api-cli file list --content-type "image/jpeg" --folder-path /photos
-  cloud-storage-api-cli file list --page 1 --size 20 --sort "createdAt,desc"`,
+  cloud-storage-api-cli file list --page 1 --size 20 --sort "createdAt,desc"
+
+  # Walk every page; files are printed as they arrive in any output format
+  cloud-storage-api-cli file list --all
+  cloud-storage-api-cli file list --all -o ndjson > files.ndjson
+  cloud-storage-api-cli file list --all -o csv > files.csv
+  cloud-storage-api-cli file list --limit 500
+
+  # Filter on size, dates and name
+  cloud-storage-api-cli file list --min-size 10MB --updated-since 7d
+  cloud-storage-api-cli file list --name-glob "*.jpg" --created-after 2025-01-01 --created-before 2025-02-01`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
@@ -163,10 +466,17 @@ Examples:
 			}
 		}
 
+		filter, err := filterFlags(cmd)
+		if err != nil {
+			return err
+		}
+		paging, pageSize, limit, err := pagingFlags(cmd, !filter.IsEmpty())
+		if err != nil {
+			return err
+		}
+
 		// Build query parameters
 		params := url.Values{}
-		params.Set("page", strconv.Itoa(page))
-		params.Set("size", strconv.Itoa(size))
 		if sort != "" {
 			params.Set("sort", sort)
 		}
@@ -177,33 +487,34 @@ Examples:
 			params.Set("folderPath", folderPath)
 		}
 
-		// Build URL with query parameters
-		path := "/api/files"
-		if len(params) > 0 {
-			path += "?" + params.Encode()
-		}
-
 		// Create API client
-		apiClient, err := client.NewClient()
+		apiClient, err := newAPIClient()
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %w", err)
 		}
 
+		if paging {
+			return streamFileList(cmd.Context(), apiClient, "/api/files", params, pageSize, limit, filter)
+		}
+
+		// Build URL with query parameters
+		params.Set("page", strconv.Itoa(page))
+		params.Set("size", strconv.Itoa(size))
+		path := "/api/files?" + params.Encode()
+
 		// Fetch file list
 		var pageResp file.PageResponse
-		if err := apiClient.Get(path, &pageResp); err != nil {
+		if err := apiClient.GetContext(cmd.Context(), path, &pageResp); err != nil {
 			return fmt.Errorf("failed to list files: %w", err)
 		}
 
-		// Check if JSON output is requested
-		if jsonOutput {
-			return util.OutputJSON(pageResp)
-		}
-
-		// Display results
-		displayFileList(&pageResp)
-
-		return nil
+		listed := newListedPage(pageResp)
+		return printResult(&util.Result{
+			Data:  listed,
+			Items: listed.Content,
+			Table: fileTable(pageResp.Content),
+			Text:  func() { displayFileList(&pageResp) },
+		})
 	},
 }
 
@@ -212,6 +523,8 @@ var fileSearchCmd = &cobra.Command{
 	Use:   "search <query>",
 	Short: "Search files by filename",
 	Long: `Search files by filename with pagination and optional filtering options.
+Use --all or --limit to walk every page of results. Size, date and name filters
+(see 'file list --help') always walk every page.
 
 The search query will match files whose filename contains the query string.
 
@@ -219,7 +532,9 @@ Examples:
   cloud-storage-api-cli file search document
   cloud-storage-api-cli file search photo --page 0 --size 50
   cloud-storage-api-cli file search report --content-type "application/pdf" --folder-path /documents
-  cloud-storage-api-cli file search image --page 1 --size 20`,
+  cloud-storage-api-cli file search image --page 1 --size 20
+  cloud-storage-api-cli file search invoice --all -o ndjson
+  cloud-storage-api-cli file search invoice --max-size 1MB --created-after 30d`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := args[0]
@@ -249,11 +564,18 @@ Examples:
 			}
 		}
 
+		filter, err := filterFlags(cmd)
+		if err != nil {
+			return err
+		}
+		paging, pageSize, limit, err := pagingFlags(cmd, !filter.IsEmpty())
+		if err != nil {
+			return err
+		}
+
 		// Build query parameters
 		params := url.Values{}
 		params.Set("q", query)
-		params.Set("page", strconv.Itoa(page))
-		params.Set("size", strconv.Itoa(size))
 		if contentType != "" {
 			params.Set("contentType", contentType)
 		}
@@ -261,30 +583,34 @@ Examples:
 			params.Set("folderPath", folderPath)
 		}
 
-		// Build URL with query parameters
-		path := "/api/files/search?" + params.Encode()
-
 		// Create API client
-		apiClient, err := client.NewClient()
+		apiClient, err := newAPIClient()
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %w", err)
 		}
 
+		if paging {
+			return streamFileList(cmd.Context(), apiClient, "/api/files/search", params, pageSize, limit, filter)
+		}
+
+		// Build URL with query parameters
+		params.Set("page", strconv.Itoa(page))
+		params.Set("size", strconv.Itoa(size))
+		path := "/api/files/search?" + params.Encode()
+
 		// Fetch search results
 		var pageResp file.PageResponse
-		if err := apiClient.Get(path, &pageResp); err != nil {
+		if err := apiClient.GetContext(cmd.Context(), path, &pageResp); err != nil {
 			return fmt.Errorf("search failed: %w", err)
 		}
 
-		// Check if JSON output is requested
-		if jsonOutput {
-			return util.OutputJSON(pageResp)
-		}
-
-		// Display results
-		displayFileList(&pageResp)
-
-		return nil
+		listed := newListedPage(pageResp)
+		return printResult(&util.Result{
+			Data:  listed,
+			Items: listed.Content,
+			Table: fileTable(pageResp.Content),
+			Text:  func() { displayFileList(&pageResp) },
+		})
 	},
 }
 
@@ -324,7 +650,7 @@ Examples:
 		}
 
 		// Create API client
-		apiClient, err := client.NewClient()
+		apiClient, err := newAPIClient()
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %w", err)
 		}
@@ -334,36 +660,33 @@ Examples:
 		if err := util.ValidateUUID(identifier); err == nil {
 			// It's a UUID - use existing URL endpoint
 			path := fmt.Sprintf("/api/files/%s/url?expirationMinutes=%d", identifier, expirationMinutes)
-			if err := apiClient.Get(path, &urlResp); err != nil {
+			if err := apiClient.GetContext(cmd.Context(), path, &urlResp); err != nil {
 				return fmt.Errorf("failed to get file URL: %w", err)
 			}
 		} else {
 			// It's a filepath - use new url-by-path endpoint
 			encodedPath := url.QueryEscape(identifier)
 			path := fmt.Sprintf("/api/files/url-by-path?filepath=%s&expirationMinutes=%d", encodedPath, expirationMinutes)
-			if err := apiClient.Get(path, &urlResp); err != nil {
+			if err := apiClient.GetContext(cmd.Context(), path, &urlResp); err != nil {
 				return fmt.Errorf("failed to get file URL: %w", err)
 			}
 		}
 
-		// Check if JSON output is requested
-		if jsonOutput {
-			return util.OutputJSON(urlResp)
-		}
-
-		// Display URL information
-		fmt.Println("Signed Download URL:")
-		fmt.Println("====================")
-		fmt.Printf("URL:           %s\n", urlResp.URL)
-		fmt.Printf("Expires At:    %s\n", urlResp.ExpiresAt.Format(time.RFC3339))
-		if urlResp.Format != "" {
-			fmt.Printf("Format:        %s\n", urlResp.Format)
-		}
-		if urlResp.ResourceType != "" {
-			fmt.Printf("Resource Type: %s\n", urlResp.ResourceType)
-		}
-
-		return nil
+		return printResult(&util.Result{
+			Data: urlResp,
+			Text: func() {
+				fmt.Println("Signed Download URL:")
+				fmt.Println("====================")
+				fmt.Printf("URL:           %s\n", urlResp.URL)
+				fmt.Printf("Expires At:    %s\n", urlResp.ExpiresAt.Format(time.RFC3339))
+				if urlResp.Format != "" {
+					fmt.Printf("Format:        %s\n", urlResp.Format)
+				}
+				if urlResp.ResourceType != "" {
+					fmt.Printf("Resource Type: %s\n", urlResp.ResourceType)
+				}
+			},
+		})
 	},
 }
 
@@ -378,26 +701,21 @@ Examples:
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Create API client
-		apiClient, err := client.NewClient()
+		apiClient, err := newAPIClient()
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %w", err)
 		}
 
 		// Fetch file information
 		var fileInfo file.FileStatisticsResponse
-		if err := apiClient.Get("/api/files/statistics", &fileInfo); err != nil {
+		if err := apiClient.GetContext(cmd.Context(), "/api/files/statistics", &fileInfo); err != nil {
 			return fmt.Errorf("failed to get file information: %w", err)
 		}
 
-		// Check if JSON output is requested
-		if jsonOutput {
-			return util.OutputJSON(fileInfo)
-		}
-
-		// Display file information
-		displayFileInfo(&fileInfo)
-
-		return nil
+		return printResult(&util.Result{
+			Data: fileInfo,
+			Text: func() { displayFileInfo(&fileInfo) },
+		})
 	},
 }
 
@@ -482,45 +800,9 @@ func displayFileList(pageResp *file.PageResponse) {
 		actualPages,
 		actualTotal)
 
-	// Print table header
-	fmt.Printf("%-36s %-30s %-20s %-12s %-20s %-20s\n",
-		"ID", "Filename", "Content Type", "Size", "Folder", "Created At")
-	fmt.Println(strings.Repeat("-", 140))
-
-	// Print table rows
+	printFileTableHeader()
 	for _, f := range pageResp.Content {
-		// Truncate ID to 36 chars (UUID length)
-		id := f.ID
-		if len(id) > 36 {
-			id = id[:36]
-		}
-
-		// Truncate filename if too long
-		filename := f.Filename
-		if len(filename) > 30 {
-			filename = filename[:27] + "..."
-		}
-
-		// Truncate content type if too long
-		contentType := f.ContentType
-		if len(contentType) > 20 {
-			contentType = contentType[:17] + "..."
-		}
-
-		// Format folder path
-		folder := "-"
-		if f.FolderPath != nil && *f.FolderPath != "" {
-			folder = *f.FolderPath
-			if len(folder) > 20 {
-				folder = folder[:17] + "..."
-			}
-		}
-
-		// Format date
-		createdAt := f.CreatedAt.Format("2006-01-02 15:04:05")
-
-		fmt.Printf("%-36s %-30s %-20s %-12s %-20s %-20s\n",
-			id, filename, contentType, util.FormatFileSize(f.FileSize), folder, createdAt)
+		printFileTableRow(&f)
 	}
 
 	// Print pagination info
@@ -548,6 +830,221 @@ func displayFileList(pageResp *file.PageResponse) {
 	fmt.Println()
 }
 
+// printFileTableHeader prints the column headings of the file table
+func printFileTableHeader() {
+	fmt.Printf("%-36s %-30s %-20s %-12s %-20s %-20s\n",
+		"ID", "Filename", "Content Type", "Size", "Folder", "Created At")
+	fmt.Println(strings.Repeat("-", 140))
+}
+
+// printFileTableRow prints one file as a row of the file table
+func printFileTableRow(f *file.FileResponse) {
+	// Truncate ID to 36 chars (UUID length)
+	id := f.ID
+	if len(id) > 36 {
+		id = id[:36]
+	}
+
+	// Truncate filename if too long
+	filename := tableFilename(f, 30)
+
+	// Truncate content type if too long
+	contentType := f.ContentType
+	if len(contentType) > 20 {
+		contentType = contentType[:17] + "..."
+	}
+
+	// Format folder path
+	folder := "-"
+	if f.FolderPath != nil && *f.FolderPath != "" {
+		folder = *f.FolderPath
+		if len(folder) > 20 {
+			folder = folder[:17] + "..."
+		}
+	}
+
+	// Format date
+	createdAt := f.CreatedAt.Format("2006-01-02 15:04:05")
+
+	fmt.Printf("%-36s %-30s %-20s %-12s %-20s %-20s\n",
+		id, filename, contentType, util.FormatFileSize(f.FileSize), folder, createdAt)
+}
+
+// fileColumns are the columns of file listings in the wide and csv output formats
+var fileColumns = []util.Column{
+	{Header: "ID", Width: 36},
+	{Header: "FILENAME", Width: 30},
+	{Header: "CONTENT TYPE", Width: 20},
+	{Header: "SIZE", Width: 12},
+	{Header: "FOLDER", Width: 20},
+	{Header: "CREATED AT", Width: 20},
+	{Header: "UPDATED AT", Width: 20, Wide: true},
+	{Header: "ENCRYPTED", Width: 9, Wide: true},
+	{Header: "URL", Wide: true},
+}
+
+// fileRow returns the cells of one file for fileColumns
+func fileRow(f *file.FileResponse) []string {
+	folder := "/"
+	if f.FolderPath != nil && *f.FolderPath != "" {
+		folder = *f.FolderPath
+	}
+	filename := f.Filename
+	if humanOutput() {
+		filename = tableFilename(f, fileColumns[1].Width)
+	}
+	return []string{
+		f.ID,
+		filename,
+		f.ContentType,
+		util.FormatFileSize(f.FileSize),
+		folder,
+		f.CreatedAt.Format("2006-01-02 15:04:05"),
+		f.UpdatedAt.Format("2006-01-02 15:04:05"),
+		fmt.Sprintf("%t", envelope.IsEncryptedName(f.Filename)),
+		f.CloudinarySecureUrl,
+	}
+}
+
+// fileTable returns files as a table for the wide and csv output formats
+func fileTable(files []file.FileResponse) *util.Table {
+	table := &util.Table{Columns: fileColumns}
+	for i := range files {
+		table.Rows = append(table.Rows, fileRow(&files[i]))
+	}
+	return table
+}
+
+// streamFileList walks every page of a file listing and prints the files matching
+// filter as they arrive, stopping after limit matches (0 for no limit). Every output
+// format is written item by item, so the full listing is never held in memory.
+func streamFileList(ctx context.Context, apiClient *client.Client, path string, params url.Values, pageSize, limit int, filter file.Filter) error {
+	// Fetch small pages for small limits, unless filtering may skip most files
+	if limit > 0 && limit < pageSize && filter.IsEmpty() {
+		pageSize = limit
+	}
+	pager := apiClient.NewFilePager(path, params)
+	pager.SetPageSize(pageSize)
+
+	human := humanOutput()
+	stream := currentStreamFormatter().Stream(os.Stdout, fileColumns)
+	count, scanned := 0, 0
+	for (limit == 0 || count < limit) && pager.Next(ctx) {
+		f := pager.File()
+		scanned++
+		if !filter.Match(&f) {
+			continue
+		}
+		if human && count == 0 {
+			fmt.Println()
+		}
+		if err := stream.Write(newListedFile(f), fileRow(&f)); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := pager.Err(); err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+	if err := stream.Close(); err != nil {
+		return err
+	}
+
+	if !human {
+		return nil
+	}
+	if count == 0 {
+		fmt.Println("No files found.")
+		return nil
+	}
+	fmt.Println()
+	if filter.IsEmpty() {
+		fmt.Printf("Showing %d of %d files\n", count, pager.TotalElements)
+	} else {
+		fmt.Printf("Showing %d matching files (%d of %d scanned)\n", count, scanned, pager.TotalElements)
+	}
+	return nil
+}
+
+// pagingFlags reads --all, --limit and --size for list commands
+// It returns paging=false when a single page was requested; filtered listings
+// always page, since a single page may hold none of the matches.
+func pagingFlags(cmd *cobra.Command, filtered bool) (paging bool, pageSize, limit int, err error) {
+	all, _ := cmd.Flags().GetBool("all")
+	limit, _ = cmd.Flags().GetInt("limit")
+	pageSize, _ = cmd.Flags().GetInt("size")
+
+	if limit < 0 {
+		return false, 0, 0, fmt.Errorf("--limit must not be negative")
+	}
+	if !all && limit == 0 && !filtered {
+		return false, pageSize, 0, nil
+	}
+	if cmd.Flags().Changed("page") {
+		return false, 0, 0, fmt.Errorf("--page cannot be used with --all, --limit or filters")
+	}
+	// Walk with the largest pages the API allows unless --size was given
+	if !cmd.Flags().Changed("size") {
+		pageSize = 100
+	}
+	return true, pageSize, limit, nil
+}
+
+// addFilterFlags adds the client-side file filter flags to a list command
+func addFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String("min-size", "", "Only files of at least this size (e.g., 10MB)")
+	cmd.Flags().String("max-size", "", "Only files of at most this size (e.g., 1.5GB)")
+	cmd.Flags().String("created-after", "", "Only files created at or after this time (e.g., 2025-01-31 or 30d)")
+	cmd.Flags().String("created-before", "", "Only files created before this time (e.g., 2025-02-01 or 7d)")
+	cmd.Flags().String("updated-since", "", "Only files updated at or after this time (e.g., 7d or 2025-01-31)")
+	cmd.Flags().String("name-glob", "", "Only files whose filename matches this glob (e.g., \"*.jpg\")")
+}
+
+// filterFlags builds a file filter from the flags added by addFilterFlags
+func filterFlags(cmd *cobra.Command) (file.Filter, error) {
+	var filter file.Filter
+	now := time.Now()
+
+	if value, _ := cmd.Flags().GetString("min-size"); value != "" {
+		size, err := util.ParseFileSize(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --min-size: %w", err)
+		}
+		filter.MinSize = size
+	}
+	if value, _ := cmd.Flags().GetString("max-size"); value != "" {
+		size, err := util.ParseFileSize(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --max-size: %w", err)
+		}
+		filter.MaxSize, filter.HasMaxSize = size, true
+	}
+
+	times := []struct {
+		flag   string
+		target *time.Time
+	}{
+		{"created-after", &filter.CreatedAfter},
+		{"created-before", &filter.CreatedBefore},
+		{"updated-since", &filter.UpdatedSince},
+	}
+	for _, t := range times {
+		if value, _ := cmd.Flags().GetString(t.flag); value != "" {
+			parsed, err := util.ParseTime(value, now)
+			if err != nil {
+				return filter, fmt.Errorf("invalid --%s: %w", t.flag, err)
+			}
+			*t.target = parsed
+		}
+	}
+
+	filter.NameGlob, _ = cmd.Flags().GetString("name-glob")
+	if err := filter.Validate(); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
 // fileDownloadCmd represents the file download command
 var fileDownloadCmd = &cobra.Command{
 	Use:   "download <file-id-or-path>",
@@ -558,9 +1055,29 @@ You can download by:
   - File ID (UUID): 550e8400-e29b-41d4-a716-446655440000
   - Filepath: /photos/2024/image.jpg or document.pdf (for root folder)
 
-The file will be saved to the specified output path, or to the current directory
-if no output path is provided. If the output path is a directory, the file will
-be saved with its original filename in that directory.
+The file will be saved to the --dest path, or to the current directory if no
+destination is provided. If the destination is a directory, the file will be
+saved with its original filename in that directory. (--dest was called --output
+before -o became the output format flag of every command.)
+
+A path containing wildcards (*, ?, [...]) downloads every matching file into
+the --dest directory. "**" matches any number of folders, and the folder
+layout below the pattern's first wildcard is kept locally.
+
+Data is written to a ".part" file next to the destination and moved into place
+once the download completes. With --resume, an interrupted download keeps its
+".part" file and the next run continues from where it stopped.
+
+The SHA-256 of the data is computed while streaming. A download that is shorter
+than announced, or that does not match a checksum the server provides, is
+discarded. With --checksum, the file's metadata is fetched first so its stored
+checksum can be used too, and the digest is shown.
+
+Files uploaded with --encrypt are recognised by their header and decrypted
+while downloading, and the ".enc" suffix is dropped from the saved name. The
+passphrase is taken from $CLOUD_STORAGE_ENCRYPTION_PASSPHRASE or asked for;
+use --encryption-key-file for files encrypted with a key file. An encrypted
+download cannot be resumed and starts over instead.
 
 Examples:
   # Download by UUID
@@ -570,83 +1087,181 @@ Examples:
   cloud-storage-api-cli file download /photos/2024/image.jpg
   cloud-storage-api-cli file download document.pdf
   
-  # Download with custom output
-  cloud-storage-api-cli file download /documents/report.pdf --output ./downloads/`,
+  # Download into a directory
+  cloud-storage-api-cli file download /documents/report.pdf --dest ./downloads/
+
+  # Resume an interrupted download
+  cloud-storage-api-cli file download /videos/talk.mp4 --dest ./talk.mp4 --resume
+
+  # Show and verify the SHA-256
+  cloud-storage-api-cli file download /backups/db.tar --checksum
+
+  # Decrypt a file encrypted with a key file
+  cloud-storage-api-cli file download /legal/contract.pdf.enc --encryption-key-file ~/.config/storage.key
+
+  # Download every match of a pattern into a directory
+  cloud-storage-api-cli file download '/photos/**/*.jpg' -d ./out`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		identifier := args[0]
-		outputPath, _ := cmd.Flags().GetString("output")
+		outputPath, _ := cmd.Flags().GetString("dest")
+		resume, _ := cmd.Flags().GetBool("resume")
+		checksum, _ := cmd.Flags().GetBool("checksum")
 
 		// Create API client
-		apiClient, err := client.NewClient()
+		apiClient, err := newAPIClient()
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %w", err)
 		}
 
+		// Encrypted files are decrypted transparently; the key is only needed if one is met
+		if err := configureEncryption(cmd, apiClient, false); err != nil {
+			return err
+		}
+
+		opts := client.DownloadOptions{Resume: resume}
+		if client.HasGlob(identifier) {
+			return downloadGlob(cmd.Context(), apiClient, identifier, outputPath, opts)
+		}
+
 		// Check if identifier is a UUID or filepath
-		var finalPath string
-		if err := util.ValidateUUID(identifier); err == nil {
-			// It's a UUID - use existing download endpoint
-			path := fmt.Sprintf("/api/files/%s/download", identifier)
-			finalPath, err = apiClient.DownloadFile(path, outputPath)
+		var path string
+		if checksum {
+			// Look the file up first so its stored checksum can be verified
+			f, err := apiClient.ResolveFile(cmd.Context(), identifier)
 			if err != nil {
-				return fmt.Errorf("download failed: %w", err)
+				return err
 			}
+			opts.Checksum = f.Checksum
+			path = fmt.Sprintf("/api/files/%s/download", f.ID)
+		} else if err := util.ValidateUUID(identifier); err == nil {
+			// It's a UUID - use existing download endpoint
+			path = fmt.Sprintf("/api/files/%s/download", identifier)
 		} else {
 			// It's a filepath - use new download-by-path endpoint
 			// URL encode the filepath
 			encodedPath := url.QueryEscape(identifier)
-			path := fmt.Sprintf("/api/files/download-by-path?filepath=%s", encodedPath)
-			finalPath, err = apiClient.DownloadFile(path, outputPath)
-			if err != nil {
-				return fmt.Errorf("download failed: %w", err)
-			}
+			path = fmt.Sprintf("/api/files/download-by-path?filepath=%s", encodedPath)
 		}
 
-		// Get file info for display
-		fileInfo, err := os.Stat(finalPath)
+		finalPath, digest, err := apiClient.DownloadFileDigest(cmd.Context(), path, outputPath, opts)
 		if err != nil {
-			// File was downloaded but we can't get info - still show success
-			fmt.Printf("File downloaded successfully to: %s\n", finalPath)
-			return nil
-		}
+			return fmt.Errorf("download failed: %w", err)
+		}
+
+		// The digest covers the saved file, so it also gives its size
+		result := struct {
+			Path     string                 `json:"path"`
+			Size     int64                  `json:"size"`
+			Transfer *client.TransferDigest `json:"transfer,omitempty"`
+		}{Path: finalPath, Size: digest.Size}
+		if checksum {
+			result.Transfer = digest
+		}
+
+		return printResult(&util.Result{
+			Data: result,
+			Text: func() {
+				fmt.Println("File downloaded successfully!")
+				fmt.Printf("File path: %s\n", finalPath)
+				fmt.Printf("File size: %s\n", util.FormatFileSize(digest.Size))
+				if !checksum {
+					return
+				}
+				switch {
+				case digest.Decrypted && digest.Verified:
+					fmt.Printf("SHA-256: %s (decrypted; the stored copy was verified)\n", digest.SHA256)
+				case digest.Decrypted:
+					fmt.Printf("SHA-256: %s (decrypted; not verified: the server reported no checksum)\n", digest.SHA256)
+				case digest.Verified:
+					fmt.Printf("SHA-256: %s (verified)\n", digest.SHA256)
+				default:
+					fmt.Printf("SHA-256: %s (not verified: the server reported no checksum)\n", digest.SHA256)
+				}
+			},
+		})
+	},
+}
 
-		// Display success message
-		fmt.Println("File downloaded successfully!")
-		fmt.Printf("File path: %s\n", finalPath)
-		fmt.Printf("File size: %s\n", util.FormatFileSize(fileInfo.Size()))
+// downloadGlob downloads every file matching pattern into outputDir, keeping
+// the folder layout below the pattern's fixed prefix (see client.GlobBase)
+func downloadGlob(ctx context.Context, apiClient *client.Client, pattern, outputDir string, opts client.DownloadOptions) error {
+	if outputDir == "" {
+		outputDir = "."
+	}
+	if info, err := os.Stat(outputDir); err == nil && !info.IsDir() {
+		return fmt.Errorf("--dest must be a directory when downloading a pattern: %s", outputDir)
+	}
 
-		return nil
-	},
+	files, err := expandFileGlob(ctx, apiClient, pattern)
+	if err != nil {
+		return err
+	}
+
+	// Encrypted files are saved decrypted, so they lose their suffix like single downloads do
+	prefix := strings.TrimSuffix(client.GlobBase(pattern), "/") + "/"
+	localPath := func(f *file.FileResponse) (string, error) {
+		rel := strings.TrimPrefix(client.RemoteFilePath(f), prefix)
+		if envelope.IsEncryptedName(f.Filename) {
+			rel = strings.TrimSuffix(rel, envelope.Suffix)
+		}
+		return localPathUnder(outputDir, rel)
+	}
+
+	return runGlobOperation(ctx, apiClient, pattern, files, globOperation{
+		Action:   "download",
+		Progress: "Downloading",
+		Target: func(f *file.FileResponse) string {
+			target, _ := localPath(f)
+			return target
+		},
+		Run: func(ctx context.Context, c *client.Client, f *file.FileResponse) error {
+			target, err := localPath(f)
+			if err != nil {
+				return err
+			}
+			// The listing already carries any stored checksum, so verify against it for free
+			opts := opts
+			opts.Checksum = f.Checksum
+			_, err = c.DownloadFileContext(ctx, fmt.Sprintf("/api/files/%s/download", f.ID), target, opts)
+			return err
+		},
+	})
 }
 
 // fileUpdateCmd represents the file update command
 var fileUpdateCmd = &cobra.Command{
-	Use:   "update <file-id>",
+	Use:   "update <file-id-or-path>",
 	Short: "Update file metadata",
 	Long: `Update file metadata (filename and/or folder path).
 
+The file can be given by ID (UUID) or by path (/documents/report.pdf, or
+report.pdf for the root folder). A path that matches several files is rejected;
+use the file ID in that case.
+
+A path containing wildcards (*, ?, [...]; "**" for any number of folders)
+updates every matching file after listing them and asking for confirmation
+(skip with --confirm). --filename can only be used when a single file matches.
+
 At least one of --filename or --folder-path must be provided.
 
 Examples:
   cloud-storage-api-cli file update 550e8400-e29b-41d4-a716-446655440000 --filename newname.pdf
+  cloud-storage-api-cli file update /documents/draft.pdf --filename final.pdf
   cloud-storage-api-cli file update 550e8400-e29b-41d4-a716-446655440000 --folder-path /documents
-  cloud-storage-api-cli file update 550e8400-e29b-41d4-a716-446655440000 --filename newname.pdf --folder-path /documents`,
+  cloud-storage-api-cli file update 550e8400-e29b-41d4-a716-446655440000 --filename newname.pdf --folder-path /documents
+  cloud-storage-api-cli file update '/tmp/*' --folder-path /archive`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fileID := args[0]
+		identifier := args[0]
 		filename, _ := cmd.Flags().GetString("filename")
 		folderPath, _ := cmd.Flags().GetString("folder-path")
+		confirm, _ := cmd.Flags().GetBool("confirm")
 
 		// Validate that at least one field is provided
 		if filename == "" && folderPath == "" {
 			return fmt.Errorf("at least one of --filename or --folder-path must be provided")
 		}
-
-		// Validate UUID format
-		if err := util.ValidateUUID(fileID); err != nil {
-			return err
-		}
 		// Validate filename if provided
 		if filename != "" {
 			if err := util.ValidateFilename(filename); err != nil {
@@ -670,63 +1285,89 @@ Examples:
 		}
 
 		// Create API client
-		apiClient, err := client.NewClient()
+		apiClient, err := newAPIClient()
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %w", err)
 		}
 
-		// Update file
-		path := fmt.Sprintf("/api/files/%s", fileID)
-		var fileResp file.FileResponse
-		if err := apiClient.Put(path, updateReq, &fileResp); err != nil {
-			return fmt.Errorf("update failed: %w", err)
+		if client.HasGlob(identifier) {
+			return updateGlob(cmd.Context(), apiClient, identifier, updateReq, confirm)
 		}
 
-		// Check if JSON output is requested
-		if jsonOutput {
-			return util.OutputJSON(fileResp)
+		fileID, err := apiClient.ResolveFileID(cmd.Context(), identifier)
+		if err != nil {
+			return err
 		}
 
-		// Display success message
-		fmt.Println("File updated successfully!")
-		fmt.Printf("File ID: %s\n", fileResp.ID)
-		fmt.Printf("Filename: %s\n", fileResp.Filename)
-		if fileResp.FolderPath != nil {
-			fmt.Printf("Folder Path: %s\n", *fileResp.FolderPath)
-		} else {
-			fmt.Println("Folder Path: (none)")
+		// Update file
+		path := fmt.Sprintf("/api/files/%s", fileID)
+		var fileResp file.FileResponse
+		if err := apiClient.PutContext(cmd.Context(), path, updateReq, &fileResp); err != nil {
+			return fmt.Errorf("update failed: %w", err)
 		}
-		fmt.Printf("Updated At: %s\n", fileResp.UpdatedAt.Format(time.RFC3339))
 
-		return nil
+		return printResult(&util.Result{
+			Data: fileResp,
+			Text: func() {
+				fmt.Println("File updated successfully!")
+				fmt.Printf("File ID: %s\n", fileResp.ID)
+				fmt.Printf("Filename: %s\n", fileResp.Filename)
+				if fileResp.FolderPath != nil {
+					fmt.Printf("Folder Path: %s\n", *fileResp.FolderPath)
+				} else {
+					fmt.Println("Folder Path: (none)")
+				}
+				fmt.Printf("Updated At: %s\n", fileResp.UpdatedAt.Format(time.RFC3339))
+			},
+		})
 	},
 }
 
 // fileDeleteCmd represents the file delete command
 var fileDeleteCmd = &cobra.Command{
-	Use:   "delete <file-id>",
+	Use:   "delete <file-id-or-path>",
 	Short: "Delete a file from cloud storage",
 	Long: `Delete a file from cloud storage.
 
+The file can be given by ID (UUID) or by path (/documents/report.pdf, or
+report.pdf for the root folder). A path that matches several files is rejected;
+use the file ID in that case.
+
+A path containing wildcards (*, ?, [...]; "**" for any number of folders)
+deletes every matching file. The matches are listed before the prompt.
+
 This operation cannot be undone. You will be prompted for confirmation unless
 the --confirm flag is used.
 
 Examples:
   cloud-storage-api-cli file delete 550e8400-e29b-41d4-a716-446655440000
-  cloud-storage-api-cli file delete 550e8400-e29b-41d4-a716-446655440000 --confirm`,
+  cloud-storage-api-cli file delete 550e8400-e29b-41d4-a716-446655440000 --confirm
+  cloud-storage-api-cli file delete /documents/old-report.pdf
+  cloud-storage-api-cli file delete '/logs/2024-*.gz'`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fileID := args[0]
+		identifier := args[0]
 		confirm, _ := cmd.Flags().GetBool("confirm")
 
-		// Validate UUID format
-		if err := util.ValidateUUID(fileID); err != nil {
+		// Create API client
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+
+		if client.HasGlob(identifier) {
+			return deleteGlob(cmd.Context(), apiClient, identifier, confirm)
+		}
+
+		// Resolve paths before asking, so the prompt is about an existing file
+		fileID, err := apiClient.ResolveFileID(cmd.Context(), identifier)
+		if err != nil {
 			return err
 		}
 
 		// Prompt for confirmation if not already confirmed
 		if !confirm {
-			fmt.Printf("Are you sure you want to delete file %s? This cannot be undone. (y/N): ", fileID)
+			fmt.Printf("Are you sure you want to delete file %s? This cannot be undone. (y/N): ", identifier)
 			var response string
 			fmt.Scanln(&response)
 			response = strings.ToLower(strings.TrimSpace(response))
@@ -736,25 +1377,259 @@ Examples:
 			}
 		}
 
-		// Create API client
-		apiClient, err := client.NewClient()
-		if err != nil {
-			return fmt.Errorf("failed to create API client: %w", err)
-		}
-
 		// Delete file
 		path := fmt.Sprintf("/api/files/%s", fileID)
-		if err := apiClient.Delete(path); err != nil {
+		if err := apiClient.DeleteContext(cmd.Context(), path); err != nil {
 			return fmt.Errorf("delete failed: %w", err)
 		}
 
-		// Display success message
-		fmt.Printf("File %s deleted successfully.\n", fileID)
+		return printResult(&util.Result{
+			Data: struct {
+				ID      string `json:"id"`
+				Deleted bool   `json:"deleted"`
+			}{fileID, true},
+			Text: func() { fmt.Printf("File %s deleted successfully.\n", identifier) },
+		})
+	},
+}
+
+// updateGlob applies updateReq to every file matching pattern
+func updateGlob(ctx context.Context, apiClient *client.Client, pattern string, updateReq file.FileUpdateRequest, confirm bool) error {
+	files, err := expandFileGlob(ctx, apiClient, pattern)
+	if err != nil {
+		return err
+	}
+	if updateReq.Filename != nil && len(files) > 1 {
+		return fmt.Errorf("--filename cannot be used when the pattern matches %d files", len(files))
+	}
+
+	if !confirm && !confirmGlob(pattern, "update", files) {
+		fmt.Fprintln(os.Stderr, "Update cancelled.")
+		return nil
+	}
+
+	return runGlobOperation(ctx, apiClient, pattern, files, globOperation{
+		Action: "update",
+		Target: func(f *file.FileResponse) string {
+			folder, filename := client.FileFolder(f), f.Filename
+			if updateReq.FolderPath != nil {
+				folder = *updateReq.FolderPath
+			}
+			if updateReq.Filename != nil {
+				filename = *updateReq.Filename
+			}
+			return strings.TrimSuffix(folder, "/") + "/" + filename
+		},
+		Run: func(ctx context.Context, c *client.Client, f *file.FileResponse) error {
+			var fileResp file.FileResponse
+			return c.PutContext(ctx, fmt.Sprintf("/api/files/%s", f.ID), updateReq, &fileResp)
+		},
+	})
+}
+
+// deleteGlob deletes every file matching pattern
+func deleteGlob(ctx context.Context, apiClient *client.Client, pattern string, confirm bool) error {
+	files, err := expandFileGlob(ctx, apiClient, pattern)
+	if err != nil {
+		return err
+	}
 
+	if !confirm && !confirmGlob(pattern, "delete", files) {
+		fmt.Fprintln(os.Stderr, "Deletion cancelled.")
 		return nil
+	}
+
+	return runGlobOperation(ctx, apiClient, pattern, files, globOperation{
+		Action: "delete",
+		Run: func(ctx context.Context, c *client.Client, f *file.FileResponse) error {
+			return c.DeleteContext(ctx, fmt.Sprintf("/api/files/%s", f.ID))
+		},
+	})
+}
+
+// fileMoveCmd represents the file mv command
+var fileMoveCmd = &cobra.Command{
+	Use:     "mv <source>... <destination>",
+	Aliases: []string{"move"},
+	Short:   "Move or rename files",
+	Long: `Move or rename files in cloud storage.
+
+Sources can be file IDs, paths, or patterns (see 'file delete --help').
+A destination ending in '/' is a folder: every source is moved into it and keeps
+its filename. Any other destination is the new path of a single source file,
+which renames it (and moves it if the folder differs).
+
+Examples:
+  cloud-storage-api-cli file mv /documents/draft.pdf /documents/final.pdf
+  cloud-storage-api-cli file mv /documents/draft.pdf /archive/
+  cloud-storage-api-cli file mv a.txt b.txt /archive/2024/
+  cloud-storage-api-cli file mv '/tmp/*.log' /logs/`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return moveOrCopyFiles(cmd.Context(), "move", args[:len(args)-1], args[len(args)-1])
+	},
+}
+
+// fileCopyCmd represents the file cp command
+var fileCopyCmd = &cobra.Command{
+	Use:     "cp <source>... <destination>",
+	Aliases: []string{"copy"},
+	Short:   "Copy files",
+	Long: `Copy files in cloud storage.
+
+Sources and destination work as for 'file mv'. A destination ending in '/' is a
+folder to copy into; anything else is the path of the copy of a single file.
+
+The API has no copy endpoint, so each file is downloaded and uploaded again.
+The data is streamed from the download into the upload and never written to
+local disk.
+
+Examples:
+  cloud-storage-api-cli file cp /documents/report.pdf /backup/
+  cloud-storage-api-cli file cp /documents/report.pdf /documents/report-v2.pdf
+  cloud-storage-api-cli file cp '/photos/**/*.jpg' /backup/photos/`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return moveOrCopyFiles(cmd.Context(), "copy", args[:len(args)-1], args[len(args)-1])
 	},
 }
 
+// remoteTarget is the destination of a file mv or cp
+type remoteTarget struct {
+	Folder string
+	// Filename is empty when files keep their own name
+	Filename string
+}
+
+// path returns the remote path f ends up at
+func (t remoteTarget) path(f *file.FileResponse) string {
+	filename := t.Filename
+	if filename == "" {
+		filename = f.Filename
+	}
+	return strings.TrimSuffix(t.Folder, "/") + "/" + filename
+}
+
+// parseRemoteTarget interprets a mv/cp destination
+// A trailing slash names a folder; anything else is the full path of a single file.
+func parseRemoteTarget(destination string) (remoteTarget, error) {
+	if strings.HasSuffix(destination, "/") {
+		folder := "/" + strings.Trim(destination, "/")
+		if err := util.ValidatePath(folder); err != nil {
+			return remoteTarget{}, fmt.Errorf("invalid destination folder: %w", err)
+		}
+		return remoteTarget{Folder: folder}, nil
+	}
+
+	folder, filename, err := client.SplitRemotePath(destination)
+	if err != nil {
+		return remoteTarget{}, err
+	}
+	if err := util.ValidateFilename(filename); err != nil {
+		return remoteTarget{}, fmt.Errorf("invalid destination filename: %w", err)
+	}
+	return remoteTarget{Folder: folder, Filename: filename}, nil
+}
+
+// resolveSources returns the files named by sources (IDs, paths or patterns)
+// Files named more than once are only returned once.
+func resolveSources(ctx context.Context, apiClient *client.Client, sources []string) ([]file.FileResponse, error) {
+	var files []file.FileResponse
+	seen := map[string]bool{}
+	for _, source := range sources {
+		var matches []file.FileResponse
+		if client.HasGlob(source) {
+			expanded, err := expandFileGlob(ctx, apiClient, source)
+			if err != nil {
+				return nil, err
+			}
+			matches = expanded
+		} else {
+			f, err := apiClient.ResolveFile(ctx, source)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", source, err)
+			}
+			matches = []file.FileResponse{*f}
+		}
+
+		for _, f := range matches {
+			if !seen[f.ID] {
+				seen[f.ID] = true
+				files = append(files, f)
+			}
+		}
+	}
+	return files, nil
+}
+
+// moveOrCopyFiles moves or copies the files named by sources to destination
+// action is either "move" or "copy".
+func moveOrCopyFiles(ctx context.Context, action string, sources []string, destination string) error {
+	target, err := parseRemoteTarget(destination)
+	if err != nil {
+		return err
+	}
+
+	apiClient, err := newAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	files, err := resolveSources(ctx, apiClient, sources)
+	if err != nil {
+		return err
+	}
+	if target.Filename != "" && len(files) > 1 {
+		return fmt.Errorf("cannot %s %d files to %s: end the destination with '/' to %s them into a folder",
+			action, len(files), destination, action)
+	}
+
+	run := func(ctx context.Context, c *client.Client, f *file.FileResponse) (*file.FileResponse, error) {
+		var fileResp file.FileResponse
+		filename := target.Filename
+		if filename == "" {
+			filename = f.Filename
+		}
+		if action == "copy" {
+			err := c.CopyFileContext(ctx, f.ID, target.Folder, filename, &fileResp)
+			return &fileResp, err
+		}
+		updateReq := file.FileUpdateRequest{FolderPath: &target.Folder, Filename: &filename}
+		err := c.PutContext(ctx, fmt.Sprintf("/api/files/%s", f.ID), updateReq, &fileResp)
+		return &fileResp, err
+	}
+
+	// A single named file gets the same output as the other single-file commands
+	if len(sources) == 1 && !client.HasGlob(sources[0]) {
+		fileResp, err := run(ctx, apiClient, &files[0])
+		if err != nil {
+			return fmt.Errorf("%s failed: %w", action, err)
+		}
+		return printResult(&util.Result{
+			Data: fileResp,
+			Text: func() {
+				fmt.Printf("File %s successfully!\n", pastTense(action))
+				fmt.Printf("File ID: %s\n", fileResp.ID)
+				fmt.Printf("From: %s\n", client.RemoteFilePath(&files[0]))
+				fmt.Printf("To: %s\n", target.path(&files[0]))
+			},
+		})
+	}
+
+	op := globOperation{
+		Action: action,
+		Target: target.path,
+		Run: func(ctx context.Context, c *client.Client, f *file.FileResponse) error {
+			_, err := run(ctx, c, f)
+			return err
+		},
+	}
+	if action == "copy" {
+		op.Progress = "Copying"
+	}
+	return runGlobOperation(ctx, apiClient, strings.Join(sources, " "), files, op)
+}
+
 func init() {
 	// Add file command to root
 	rootCmd.AddCommand(fileCmd)
@@ -774,6 +1649,10 @@ func init() {
 	// Add delete subcommand to file command
 	fileCmd.AddCommand(fileDeleteCmd)
 
+	// Add mv and cp subcommands to file command
+	fileCmd.AddCommand(fileMoveCmd)
+	fileCmd.AddCommand(fileCopyCmd)
+
 	// Add search subcommand to file command
 	fileCmd.AddCommand(fileSearchCmd)
 
@@ -786,6 +1665,10 @@ func init() {
 	// Add flags to upload command
 	fileUploadCmd.Flags().String("folder-path", "", "Optional folder path (Unix-style, e.g., /photos/2024)")
 	fileUploadCmd.Flags().String("filename", "", "Custom filename (optional, defaults to original filename)")
+	fileUploadCmd.Flags().BoolP("recursive", "r", false, "Upload a directory and all of its subdirectories")
+	fileUploadCmd.Flags().Bool("checksum", false, "Show the SHA-256 of uploaded files and read back any the server does not checksum")
+	fileUploadCmd.Flags().Bool("encrypt", false, "Encrypt files with AES-256-GCM before uploading them")
+	addEncryptionKeyFlag(fileUploadCmd)
 
 	// Add flags to list command
 	fileListCmd.Flags().Int("page", 0, "Page number (0-indexed, default: 0)")
@@ -793,13 +1676,20 @@ func init() {
 	fileListCmd.Flags().String("sort", "createdAt,desc", "Sort field and direction (e.g., createdAt,desc)")
 	fileListCmd.Flags().String("content-type", "", "Filter by content type (e.g., image/jpeg)")
 	fileListCmd.Flags().String("folder-path", "", "Filter by folder path (e.g., /photos/2024)")
+	fileListCmd.Flags().Bool("all", false, "List all files, walking every page")
+	fileListCmd.Flags().Int("limit", 0, "List at most this many files, walking pages as needed")
+	addFilterFlags(fileListCmd)
 
 	// Add flags to download command
-	fileDownloadCmd.Flags().StringP("output", "o", "", "Output file path or directory (default: current directory)")
+	fileDownloadCmd.Flags().StringP("dest", "d", "", "Destination file path or directory (default: current directory)")
+	fileDownloadCmd.Flags().Bool("resume", false, "Resume an interrupted download from its .part file")
+	fileDownloadCmd.Flags().Bool("checksum", false, "Verify against the file's stored checksum and show the SHA-256")
+	addEncryptionKeyFlag(fileDownloadCmd)
 
 	// Add flags to update command
 	fileUpdateCmd.Flags().String("filename", "", "New filename")
 	fileUpdateCmd.Flags().String("folder-path", "", "New folder path (Unix-style, e.g., /photos/2024)")
+	fileUpdateCmd.Flags().BoolP("confirm", "y", false, "Skip confirmation prompt when a pattern matches several files")
 
 	// Add flags to delete command
 	fileDeleteCmd.Flags().BoolP("confirm", "y", false, "Skip confirmation prompt")
@@ -809,6 +1699,9 @@ func init() {
 	fileSearchCmd.Flags().Int("size", 20, "Page size (default: 20, max: 100)")
 	fileSearchCmd.Flags().String("content-type", "", "Filter by content type (e.g., image/jpeg)")
 	fileSearchCmd.Flags().String("folder-path", "", "Filter by folder path (e.g., /photos/2024)")
+	fileSearchCmd.Flags().Bool("all", false, "Return all matches, walking every page")
+	fileSearchCmd.Flags().Int("limit", 0, "Return at most this many matches, walking pages as needed")
+	addFilterFlags(fileSearchCmd)
 
 	// Add flags to url command
 	fileUrlCmd.Flags().Int("expiration-minutes", 60, "URL expiration time in minutes (default: 60, max: 1440)")
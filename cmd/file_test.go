@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -397,3 +398,109 @@ func TestFileDownload_ErrorHandling(t *testing.T) {
 		t.Error("Expected error, got nil")
 	}
 }
+
+func TestCollectUploadFiles(t *testing.T) {
+	root := testutil.CreateTestDir(t)
+	files := map[string]string{
+		"top.txt":             "top",
+		"docs/readme.md":      "readme",
+		"docs/2024/report.md": "report",
+	}
+	for rel, content := range files {
+		full := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+	}
+
+	items, err := collectUploadFiles(root, "/backup")
+	if err != nil {
+		t.Fatalf("collectUploadFiles failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"top.txt":   "/backup",
+		"readme.md": "/backup/docs",
+		"report.md": "/backup/docs/2024",
+	}
+	if len(items) != len(expected) {
+		t.Fatalf("Expected %d files, got %d", len(expected), len(items))
+	}
+	for _, item := range items {
+		name := filepath.Base(item.LocalPath)
+		if item.FolderPath != expected[name] {
+			t.Errorf("Expected %s to map to %q, got %q", name, expected[name], item.FolderPath)
+		}
+	}
+
+	// Files at the root of an upload without a destination go to the root folder
+	if got := remoteFolderFor("", "."); got != "" {
+		t.Errorf("Expected empty folder path for root files, got %q", got)
+	}
+	if got := remoteFolderFor("", "docs"); got != "/docs" {
+		t.Errorf("Expected /docs, got %q", got)
+	}
+}
+
+func TestTableFilename(t *testing.T) {
+	tests := []struct {
+		name  string
+		width int
+		want  string
+	}{
+		{"report.pdf", 30, "report.pdf"},
+		{"report.pdf.enc", 30, "report.pdf.enc [encrypted]"},
+		{"a-very-long-contract-name.pdf.enc", 30, "a-very-long-con... [encrypted]"},
+		{"a-very-long-contract-name-for-2025.pdf", 30, "a-very-long-contract-name-f..."},
+		{"a-very-long-contract-name.pdf.enc", 0, "a-very-long-contract-name.pdf.enc [encrypted]"},
+		{".enc", 30, ".enc"},
+	}
+	for _, tt := range tests {
+		got := tableFilename(&file.FileResponse{Filename: tt.name}, tt.width)
+		if got != tt.want {
+			t.Errorf("tableFilename(%q, %d) = %q, want %q", tt.name, tt.width, got, tt.want)
+		}
+		if tt.width > 0 && len(got) > tt.width {
+			t.Errorf("tableFilename(%q, %d) is %d characters long", tt.name, tt.width, len(got))
+		}
+	}
+}
+
+func TestListedPage_MarksEncryptedFiles(t *testing.T) {
+	page := file.PageResponse{
+		Content:       []file.FileResponse{{ID: "file-1", Filename: "a.pdf"}, {ID: "file-2", Filename: "b.pdf.enc"}},
+		TotalElements: 2,
+	}
+
+	data, err := json.Marshal(newListedPage(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Content []struct {
+			ID        string `json:"id"`
+			Encrypted bool   `json:"encrypted"`
+		} `json:"content"`
+		TotalElements int64 `json:"totalElements"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.TotalElements != 2 || len(got.Content) != 2 {
+		t.Fatalf("Listed page lost its fields: %s", data)
+	}
+	if got.Content[0].ID != "file-1" || got.Content[0].Encrypted || !got.Content[1].Encrypted {
+		t.Errorf("Expected only file-2 to be marked encrypted: %s", data)
+	}
+
+	// The csv and wide formats show the marker as a column
+	row := fileRow(&page.Content[1])
+	for i, c := range fileColumns {
+		if c.Header == "ENCRYPTED" && row[i] != "true" {
+			t.Errorf("ENCRYPTED cell = %q, want true", row[i])
+		}
+	}
+}
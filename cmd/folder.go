@@ -16,6 +16,7 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"sort"
@@ -37,7 +38,7 @@ var folderCmd = &cobra.Command{
 Available commands:
   create - Create a new folder
   list   - List all folders
-  delete - Delete an empty folder
+  delete - Delete an empty folder, or a folder and its contents with --recursive
   info   - Display folder information (alias: stats)`,
 }
 
@@ -64,7 +65,7 @@ Examples:
 		}
 
 		// Create API client
-		apiClient, err := client.NewClient()
+		apiClient, err := newAPIClient()
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %w", err)
 		}
@@ -79,25 +80,22 @@ Examples:
 
 		// Create folder
 		var folderResp file.FolderResponse
-		if err := apiClient.Post("/api/folders", createReq, &folderResp); err != nil {
+		if err := apiClient.PostContext(cmd.Context(), "/api/folders", createReq, &folderResp); err != nil {
 			return fmt.Errorf("failed to create folder: %w", err)
 		}
 
-		// Check if JSON output is requested
-		if jsonOutput {
-			return util.OutputJSON(folderResp)
-		}
-
-		// Display success message
-		fmt.Println("Folder created successfully!")
-		fmt.Printf("Path: %s\n", folderResp.Path)
-		if folderResp.Description != nil {
-			fmt.Printf("Description: %s\n", *folderResp.Description)
-		}
-		fmt.Printf("File Count: %d\n", folderResp.FileCount)
-		fmt.Printf("Created At: %s\n", folderResp.CreatedAt.Format(time.RFC3339))
-
-		return nil
+		return printResult(&util.Result{
+			Data: folderResp,
+			Text: func() {
+				fmt.Println("Folder created successfully!")
+				fmt.Printf("Path: %s\n", folderResp.Path)
+				if folderResp.Description != nil {
+					fmt.Printf("Description: %s\n", *folderResp.Description)
+				}
+				fmt.Printf("File Count: %d\n", folderResp.FileCount)
+				fmt.Printf("Created At: %s\n", folderResp.CreatedAt.Format(time.RFC3339))
+			},
+		})
 	},
 }
 
@@ -125,7 +123,7 @@ Examples:
 		}
 
 		// Create API client
-		apiClient, err := client.NewClient()
+		apiClient, err := newAPIClient()
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %w", err)
 		}
@@ -133,44 +131,51 @@ Examples:
 		// Fetch folder list
 		// Note: API returns List<FolderResponse> which is serialized as JSON array
 		var folders []file.FolderResponse
-		if err := apiClient.Get(path, &folders); err != nil {
+		if err := apiClient.GetContext(cmd.Context(), path, &folders); err != nil {
 			return fmt.Errorf("failed to list folders: %w", err)
 		}
 
-		// Check if JSON output is requested
-		if jsonOutput {
-			return util.OutputJSON(folders)
-		}
-
-		// Display results
-		displayFolderList(folders)
-
-		return nil
+		return printResult(&util.Result{
+			Data:  folders,
+			Table: folderTable(folders),
+			Text:  func() { displayFolderList(folders) },
+		})
 	},
 }
 
 // folderDeleteCmd represents the folder delete command
 var folderDeleteCmd = &cobra.Command{
 	Use:   "delete <path>",
-	Short: "Delete an empty folder",
+	Short: "Delete a folder",
 	Long: `Delete a folder from cloud storage.
 
-The folder must be empty (no files) to be deleted. This operation cannot be undone.
-You will be prompted for confirmation unless the --force flag is used.
+The folder must be empty (no files) to be deleted, unless --recursive is given.
+With --recursive, every file in the folder and its subfolders is deleted (in
+parallel, see --concurrency), then the folders themselves from the deepest up.
+Items that fail are reported and their parent folders are kept.
+
+This operation cannot be undone. You will be prompted for confirmation, showing
+what will be deleted, unless the --force flag is used.
 
 Examples:
   cloud-storage-api-cli folder delete /photos/2024
-  cloud-storage-api-cli folder delete /photos/2024 --force`,
+  cloud-storage-api-cli folder delete /photos/2024 --force
+  cloud-storage-api-cli folder delete /photos/2023 --recursive`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		path := args[0]
 		force, _ := cmd.Flags().GetBool("force")
+		recursive, _ := cmd.Flags().GetBool("recursive")
 
 		// Validate path
 		if err := util.ValidatePath(path); err != nil {
 			return err
 		}
 
+		if recursive {
+			return deleteFolderRecursive(cmd.Context(), path, force)
+		}
+
 		// Prompt for confirmation if not forced
 		if !force {
 			fmt.Printf("Are you sure you want to delete folder '%s'? This cannot be undone. (y/N): ", path)
@@ -189,23 +194,257 @@ Examples:
 		apiPath := "/api/folders?" + params.Encode()
 
 		// Create API client
-		apiClient, err := client.NewClient()
+		apiClient, err := newAPIClient()
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %w", err)
 		}
 
 		// Delete folder
-		if err := apiClient.Delete(apiPath); err != nil {
+		if err := apiClient.DeleteContext(cmd.Context(), apiPath); err != nil {
 			return fmt.Errorf("failed to delete folder: %w", err)
 		}
 
-		// Display success message
-		fmt.Printf("Folder '%s' deleted successfully.\n", path)
-
-		return nil
+		return printResult(&util.Result{
+			Data: struct {
+				Path    string `json:"path"`
+				Deleted bool   `json:"deleted"`
+			}{path, true},
+			Text: func() { fmt.Printf("Folder '%s' deleted successfully.\n", path) },
+		})
 	},
 }
 
+// folderDeleteResult is the outcome of deleting one file or folder of a recursive delete
+type folderDeleteResult struct {
+	Type    string `json:"type"`
+	Path    string `json:"path"`
+	ID      string `json:"id,omitempty"`
+	Size    int64  `json:"size,omitempty"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// folderDeleteSummary reports the outcome of a recursive folder delete
+type folderDeleteSummary struct {
+	Path       string               `json:"path"`
+	Files      int                  `json:"files"`
+	Folders    int                  `json:"folders"`
+	TotalBytes int64                `json:"totalBytes"`
+	Deleted    int                  `json:"deleted"`
+	Failed     int                  `json:"failed"`
+	Results    []folderDeleteResult `json:"results"`
+}
+
+// deleteFolderRecursive deletes root with all of its files and subfolders
+func deleteFolderRecursive(ctx context.Context, root string, force bool) error {
+	if root == "/" {
+		return fmt.Errorf("refusing to recursively delete the root folder")
+	}
+
+	apiClient, err := newAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	folders, files, err := collectFolderTree(ctx, apiClient, root)
+	if err != nil {
+		return err
+	}
+
+	var totalBytes int64
+	for _, f := range files {
+		totalBytes += f.FileSize
+	}
+
+	// Prompt for confirmation if not forced
+	if !force {
+		fmt.Printf("Folder '%s' contains %d files (%s) in %d folders.\n",
+			root, len(files), util.FormatFileSize(totalBytes), len(folders))
+		fmt.Print("Are you sure you want to delete all of them? This cannot be undone. (y/N): ")
+		var response string
+		fmt.Scanln(&response)
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Delete cancelled.")
+			return nil
+		}
+	}
+
+	summary := deleteFolderTree(ctx, apiClient, root, folders, files)
+	err = printResult(&util.Result{
+		Data:  summary,
+		Items: summary.Results,
+		Text:  func() { displayFolderDeleteSummary(&summary) },
+	})
+	if err != nil {
+		return err
+	}
+
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d items could not be deleted", summary.Failed, len(summary.Results))
+	}
+	return nil
+}
+
+// collectFolderTree returns root and its subfolders (via /api/folders?parentPath=)
+// and every file stored in them
+func collectFolderTree(ctx context.Context, apiClient *client.Client, root string) ([]string, []file.FileResponse, error) {
+	folders, err := apiClient.WalkFolders(ctx, root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var files []file.FileResponse
+	for _, folder := range folders {
+		folderFiles, err := apiClient.ListFolderFiles(ctx, folder)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, folderFiles...)
+	}
+	return folders, files, nil
+}
+
+// deleteFolderTree deletes files concurrently, then folders from the deepest up
+// A folder is only deleted once everything beneath it was deleted; folders
+// left non-empty by a failure are reported as failed without being attempted.
+func deleteFolderTree(ctx context.Context, apiClient *client.Client, root string, folders []string, files []file.FileResponse) folderDeleteSummary {
+	summary := folderDeleteSummary{
+		Path:    root,
+		Files:   len(files),
+		Folders: len(folders),
+	}
+
+	// Folders that still hold something after a failed delete
+	blocked := map[string]bool{}
+	block := func(folder string) {
+		for _, f := range folders {
+			if f == folder || client.IsUnderFolder(folder, f) {
+				blocked[f] = true
+			}
+		}
+	}
+
+	jobs := make([]client.TransferJob, len(files))
+	for i := range files {
+		f := files[i]
+		summary.TotalBytes += f.FileSize
+		jobs[i] = client.TransferJob{
+			Name: f.ID,
+			Run: func(ctx context.Context, c *client.Client) error {
+				return c.DeleteContext(ctx, fmt.Sprintf("/api/files/%s", f.ID))
+			},
+		}
+	}
+	scheduler := client.NewTransferScheduler(apiClient, concurrency)
+	scheduler.SetShowProgress(false)
+	for i, res := range scheduler.Run(ctx, jobs) {
+		f := &files[i]
+		result := folderDeleteResult{
+			Type: "file",
+			Path: client.RemoteFilePath(f),
+			ID:   f.ID,
+			Size: f.FileSize,
+		}
+		if res.Err != nil {
+			result.Error = res.Err.Error()
+			block(client.FileFolder(f))
+		} else {
+			result.Success = true
+		}
+		summary.Results = append(summary.Results, result)
+	}
+
+	// Each depth level only depends on the levels below it, so a level's
+	// folders can be deleted in parallel
+	for _, level := range foldersBottomUp(folders) {
+		var pending []string
+		for _, folder := range level {
+			if blocked[folder] {
+				summary.Results = append(summary.Results, folderDeleteResult{
+					Type:  "folder",
+					Path:  folder,
+					Error: "not deleted: folder still has contents",
+				})
+				continue
+			}
+			pending = append(pending, folder)
+		}
+
+		jobs := make([]client.TransferJob, len(pending))
+		for i, folder := range pending {
+			params := url.Values{}
+			params.Set("path", folder)
+			apiPath := "/api/folders?" + params.Encode()
+			jobs[i] = client.TransferJob{
+				Name: folder,
+				Run: func(ctx context.Context, c *client.Client) error {
+					return c.DeleteContext(ctx, apiPath)
+				},
+			}
+		}
+		for i, res := range scheduler.Run(ctx, jobs) {
+			result := folderDeleteResult{Type: "folder", Path: pending[i]}
+			if res.Err != nil {
+				result.Error = res.Err.Error()
+				block(pending[i])
+			} else {
+				result.Success = true
+			}
+			summary.Results = append(summary.Results, result)
+		}
+	}
+
+	for _, r := range summary.Results {
+		if r.Success {
+			summary.Deleted++
+		} else {
+			summary.Failed++
+		}
+	}
+	return summary
+}
+
+// foldersBottomUp groups folders by depth, deepest level first
+func foldersBottomUp(folders []string) [][]string {
+	byDepth := map[int][]string{}
+	maxDepth := 0
+	for _, folder := range folders {
+		depth := strings.Count(strings.TrimSuffix(folder, "/"), "/")
+		byDepth[depth] = append(byDepth[depth], folder)
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+	}
+
+	var levels [][]string
+	for depth := maxDepth; depth >= 0; depth-- {
+		if level, ok := byDepth[depth]; ok {
+			sort.Strings(level)
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
+
+// displayFolderDeleteSummary prints the failures and totals of a recursive delete
+func displayFolderDeleteSummary(summary *folderDeleteSummary) {
+	for _, r := range summary.Results {
+		if !r.Success {
+			fmt.Printf("✗ %s %s: %s\n", r.Type, r.Path, r.Error)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("Delete Summary")
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Folder:      %s\n", summary.Path)
+	fmt.Printf("Files:       %d (%s)\n", summary.Files, util.FormatFileSize(summary.TotalBytes))
+	fmt.Printf("Folders:     %d\n", summary.Folders)
+	fmt.Printf("Deleted:     %d\n", summary.Deleted)
+	fmt.Printf("Failed:      %d\n", summary.Failed)
+}
+
 // folderInfoCmd represents the folder info command
 var folderInfoCmd = &cobra.Command{
 	Use:     "info <path>",
@@ -231,26 +470,21 @@ Examples:
 		apiPath := "/api/folders/statistics?" + params.Encode()
 
 		// Create API client
-		apiClient, err := client.NewClient()
+		apiClient, err := newAPIClient()
 		if err != nil {
 			return fmt.Errorf("failed to create API client: %w", err)
 		}
 
 		// Fetch folder information
 		var folderInfo file.FolderStatisticsResponse
-		if err := apiClient.Get(apiPath, &folderInfo); err != nil {
+		if err := apiClient.GetContext(cmd.Context(), apiPath, &folderInfo); err != nil {
 			return fmt.Errorf("failed to get folder information: %w", err)
 		}
 
-		// Check if JSON output is requested
-		if jsonOutput {
-			return util.OutputJSON(folderInfo)
-		}
-
-		// Display folder information
-		displayFolderInfo(&folderInfo)
-
-		return nil
+		return printResult(&util.Result{
+			Data: folderInfo,
+			Text: func() { displayFolderInfo(&folderInfo) },
+		})
 	},
 }
 
@@ -297,6 +531,31 @@ func displayFolderList(folders []file.FolderResponse) {
 	fmt.Println()
 }
 
+// folderTable returns the folder list as a table for the wide and csv output formats
+func folderTable(folders []file.FolderResponse) *util.Table {
+	table := &util.Table{
+		Columns: []util.Column{
+			{Header: "PATH", Width: 40},
+			{Header: "DESCRIPTION", Width: 30},
+			{Header: "FILES", Width: 10},
+			{Header: "CREATED AT", Width: 20},
+		},
+	}
+	for _, f := range folders {
+		description := ""
+		if f.Description != nil {
+			description = *f.Description
+		}
+		table.Rows = append(table.Rows, []string{
+			f.Path,
+			description,
+			fmt.Sprintf("%d", f.FileCount),
+			f.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return table
+}
+
 // displayFolderInfo displays folder information in a formatted way
 func displayFolderInfo(folderInfo *file.FolderStatisticsResponse) {
 	fmt.Println("\nFolder Information")
@@ -358,4 +617,5 @@ func init() {
 
 	// Add flags to delete command
 	folderDeleteCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	folderDeleteCmd.Flags().BoolP("recursive", "r", false, "Delete the folder's files and subfolders too")
 }
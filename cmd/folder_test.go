@@ -16,8 +16,12 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -260,3 +264,108 @@ func TestFolderStats_ErrorHandling(t *testing.T) {
 		t.Error("Expected error, got nil")
 	}
 }
+
+// setupFolderTreeServer serves /photos with a /photos/2023 subfolder holding one file each
+// Deleting a file whose ID is in failIDs is rejected.
+func setupFolderTreeServer(t *testing.T, failIDs ...string) (*client.Client, *[]string) {
+	t.Helper()
+	photos, year := "/photos", "/photos/2023"
+	files := []file.FileResponse{
+		{ID: "file-1", Filename: "a.jpg", FileSize: 1024, FolderPath: &photos},
+		{ID: "file-2", Filename: "b.jpg", FileSize: 2048, FolderPath: &year},
+	}
+
+	var mu sync.Mutex
+	var deleted []string
+	server := testutil.SetupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/folders":
+			var folders []file.FolderResponse
+			if r.URL.Query().Get("parentPath") == photos {
+				folders = append(folders, file.FolderResponse{Path: year})
+			}
+			testutil.JSONResponse(w, http.StatusOK, folders)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/files":
+			var content []file.FileResponse
+			for _, f := range files {
+				if *f.FolderPath == r.URL.Query().Get("folderPath") {
+					content = append(content, f)
+				}
+			}
+			testutil.JSONResponse(w, http.StatusOK, file.PageResponse{
+				Content: content, TotalElements: int64(len(content)), TotalPages: 1, Last: true,
+			})
+		case r.Method == http.MethodDelete:
+			target := strings.TrimPrefix(r.URL.Path, "/api/files/")
+			if r.URL.Path == "/api/folders" {
+				target = r.URL.Query().Get("path")
+			}
+			for _, id := range failIDs {
+				if id == target {
+					testutil.ErrorResponse(w, http.StatusForbidden, "Access denied")
+					return
+				}
+			}
+			mu.Lock()
+			deleted = append(deleted, target)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			testutil.ErrorResponse(w, http.StatusNotFound, "Not found")
+		}
+	})
+	t.Cleanup(server.Close)
+
+	return client.NewClientWithConfig(server.URL, "test-api-key"), &deleted
+}
+
+func TestDeleteFolderTree(t *testing.T) {
+	apiClient, deleted := setupFolderTreeServer(t)
+	ctx := context.Background()
+
+	folders, files, err := collectFolderTree(ctx, apiClient, "/photos")
+	if err != nil {
+		t.Fatalf("collectFolderTree() error = %v", err)
+	}
+	if !reflect.DeepEqual(folders, []string{"/photos", "/photos/2023"}) || len(files) != 2 {
+		t.Fatalf("Unexpected tree: folders %v, %d files", folders, len(files))
+	}
+
+	summary := deleteFolderTree(ctx, apiClient, "/photos", folders, files)
+	if summary.Deleted != 4 || summary.Failed != 0 || summary.TotalBytes != 3072 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+
+	// Folders are deleted after all files, deepest first
+	got := (*deleted)[2:]
+	if want := []string{"/photos/2023", "/photos"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Folder delete order = %v, want %v", got, want)
+	}
+}
+
+func TestDeleteFolderTree_PartialFailure(t *testing.T) {
+	apiClient, deleted := setupFolderTreeServer(t, "file-2")
+	ctx := context.Background()
+
+	folders, files, err := collectFolderTree(ctx, apiClient, "/photos")
+	if err != nil {
+		t.Fatalf("collectFolderTree() error = %v", err)
+	}
+	summary := deleteFolderTree(ctx, apiClient, "/photos", folders, files)
+
+	// file-2 fails, so neither of its folders can be deleted
+	if summary.Deleted != 1 || summary.Failed != 3 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+	if !reflect.DeepEqual(*deleted, []string{"file-1"}) {
+		t.Errorf("Deleted = %v, want only file-1", *deleted)
+	}
+}
+
+func TestFoldersBottomUp(t *testing.T) {
+	got := foldersBottomUp([]string{"/a", "/a/b", "/a/c", "/a/b/d"})
+	want := [][]string{{"/a/b/d"}, {"/a/b", "/a/c"}, {"/a"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("foldersBottomUp() = %v, want %v", got, want)
+	}
+}
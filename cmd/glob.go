@@ -0,0 +1,209 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/client"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/util"
+)
+
+// globPreviewLimit is how many matches are listed before asking for confirmation
+const globPreviewLimit = 20
+
+// globOperation describes what to do with every file matched by a pattern
+type globOperation struct {
+	// Action is the verb used in messages, e.g. "delete"
+	Action string
+	// Progress is the progress bar description; empty hides the bar
+	Progress string
+	// Target optionally describes where a file ends up (local path, new remote path)
+	Target func(f *file.FileResponse) string
+	// Run performs the operation for a single file
+	Run func(ctx context.Context, c *client.Client, f *file.FileResponse) error
+}
+
+// globResult is the outcome of one file of a pattern operation
+type globResult struct {
+	Path    string `json:"path"`
+	ID      string `json:"id"`
+	Size    int64  `json:"size"`
+	Target  string `json:"target,omitempty"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// globSummary reports the outcome of a pattern operation
+type globSummary struct {
+	Source     string       `json:"source"`
+	Action     string       `json:"action"`
+	Matched    int          `json:"matched"`
+	Succeeded  int          `json:"succeeded"`
+	Failed     int          `json:"failed"`
+	TotalBytes int64        `json:"totalBytes"`
+	Results    []globResult `json:"results"`
+}
+
+// expandFileGlob returns the files matching pattern, failing when there are none
+func expandFileGlob(ctx context.Context, apiClient *client.Client, pattern string) ([]file.FileResponse, error) {
+	files, err := apiClient.ExpandGlob(ctx, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand %s: %w", pattern, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files match %s", pattern)
+	}
+	return files, nil
+}
+
+// printGlobMatches lists the first files matched by a pattern along with their total size
+// Like the prompts that follow it, the list goes to stderr so it never mixes
+// with machine-readable output.
+func printGlobMatches(pattern string, files []file.FileResponse) {
+	var totalBytes int64
+	for _, f := range files {
+		totalBytes += f.FileSize
+	}
+
+	fmt.Fprintf(os.Stderr, "Pattern '%s' matches %d files (%s):\n", pattern, len(files), util.FormatFileSize(totalBytes))
+	for i := range files {
+		if i == globPreviewLimit {
+			fmt.Fprintf(os.Stderr, "  ... and %d more\n", len(files)-globPreviewLimit)
+			break
+		}
+		fmt.Fprintf(os.Stderr, "  %s (%s)\n", client.RemoteFilePath(&files[i]), util.FormatFileSize(files[i].FileSize))
+	}
+}
+
+// confirmGlob lists the files matched by a pattern and asks before acting on them
+// Returns true if the user confirmed.
+func confirmGlob(pattern, action string, files []file.FileResponse) bool {
+	printGlobMatches(pattern, files)
+	fmt.Fprintf(os.Stderr, "Are you sure you want to %s all of them? (y/N): ", action)
+	var response string
+	fmt.Scanln(&response)
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes"
+}
+
+// runGlobOperation applies op to every file concurrently and reports the results
+// source describes where the files came from (a pattern or a list of sources).
+// Returns an error if any file failed.
+func runGlobOperation(ctx context.Context, apiClient *client.Client, source string, files []file.FileResponse, op globOperation) error {
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+
+	summary := globSummary{
+		Source:  source,
+		Action:  op.Action,
+		Matched: len(files),
+		Results: make([]globResult, len(files)),
+	}
+
+	jobs := make([]client.TransferJob, len(files))
+	for i := range files {
+		f := &files[i]
+		summary.Results[i] = globResult{
+			Path: client.RemoteFilePath(f),
+			ID:   f.ID,
+			Size: f.FileSize,
+		}
+		if op.Target != nil {
+			summary.Results[i].Target = op.Target(f)
+		}
+		jobs[i] = client.TransferJob{
+			Name: summary.Results[i].Path,
+			Size: f.FileSize,
+			Run: func(ctx context.Context, c *client.Client) error {
+				return op.Run(ctx, c, f)
+			},
+		}
+	}
+
+	scheduler := client.NewTransferScheduler(apiClient, concurrency)
+	if op.Progress != "" {
+		scheduler.SetDescription(op.Progress)
+	} else {
+		scheduler.SetShowProgress(false)
+	}
+	for i, res := range scheduler.Run(ctx, jobs) {
+		result := &summary.Results[i]
+		if res.Err != nil {
+			result.Error = res.Err.Error()
+			summary.Failed++
+			continue
+		}
+		result.Success = true
+		summary.Succeeded++
+		summary.TotalBytes += result.Size
+	}
+
+	err := printResult(&util.Result{
+		Data:  summary,
+		Items: summary.Results,
+		Text:  func() { displayGlobSummary(&summary) },
+	})
+	if err != nil {
+		return err
+	}
+
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d files could not be %s", summary.Failed, summary.Matched, pastTense(op.Action))
+	}
+	return nil
+}
+
+// displayGlobSummary prints one line per file and the totals of a pattern operation
+func displayGlobSummary(summary *globSummary) {
+	for _, r := range summary.Results {
+		name := r.Path
+		if r.Target != "" {
+			name = fmt.Sprintf("%s -> %s", r.Path, r.Target)
+		}
+		if r.Success {
+			fmt.Printf("✓ %s (%s)\n", name, util.FormatFileSize(r.Size))
+		} else {
+			fmt.Printf("✗ %s: %s\n", name, r.Error)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("%s Summary\n", strings.ToUpper(summary.Action[:1])+summary.Action[1:])
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Source:      %s\n", summary.Source)
+	fmt.Printf("Matched:     %d\n", summary.Matched)
+	fmt.Printf("Succeeded:   %d\n", summary.Succeeded)
+	fmt.Printf("Failed:      %d\n", summary.Failed)
+	fmt.Printf("Total Size:  %s\n", util.FormatFileSize(summary.TotalBytes))
+}
+
+// pastTense turns the verbs used for pattern operations into their past tense
+func pastTense(action string) string {
+	switch {
+	case strings.HasSuffix(action, "e"):
+		return action + "d"
+	case strings.HasSuffix(action, "y"):
+		return strings.TrimSuffix(action, "y") + "ied"
+	default:
+		return action + "ed"
+	}
+}
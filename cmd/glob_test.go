@@ -0,0 +1,176 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/client"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/testutil"
+)
+
+func TestDeleteGlob(t *testing.T) {
+	apiClient, deleted := setupFolderTreeServer(t, "file-2")
+
+	err := deleteGlob(context.Background(), apiClient, "/photos/**/*.jpg", true)
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 files could not be deleted") {
+		t.Errorf("deleteGlob() error = %v, want partial failure", err)
+	}
+	if !reflect.DeepEqual(*deleted, []string{"file-1"}) {
+		t.Errorf("Deleted = %v, want only file-1", *deleted)
+	}
+
+	if err := deleteGlob(context.Background(), apiClient, "/photos/*.png", true); err == nil {
+		t.Error("deleteGlob() expected error for a pattern without matches, got nil")
+	}
+}
+
+func TestDownloadGlob_KeepsLayout(t *testing.T) {
+	photos, year := "/photos", "/photos/2023"
+	files := []file.FileResponse{
+		{ID: "file-1", Filename: "a.jpg", FolderPath: &photos},
+		{ID: "file-2", Filename: "b.jpg", FolderPath: &year},
+		{ID: "file-3", Filename: "c.png", FolderPath: &year},
+		{ID: "file-4", Filename: "d.pdf.enc", FolderPath: &photos},
+	}
+	server := testutil.SetupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/folders":
+			var folders []file.FolderResponse
+			if r.URL.Query().Get("parentPath") == photos {
+				folders = append(folders, file.FolderResponse{Path: year})
+			}
+			testutil.JSONResponse(w, http.StatusOK, folders)
+		case r.URL.Path == "/api/files":
+			var content []file.FileResponse
+			for _, f := range files {
+				if *f.FolderPath == r.URL.Query().Get("folderPath") {
+					content = append(content, f)
+				}
+			}
+			testutil.JSONResponse(w, http.StatusOK, file.PageResponse{
+				Content: content, TotalElements: int64(len(content)), TotalPages: 1, Last: true,
+			})
+		case strings.HasSuffix(r.URL.Path, "/download"):
+			w.Write([]byte(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/files/"), "/download")))
+		default:
+			testutil.ErrorResponse(w, http.StatusNotFound, "Not found")
+		}
+	})
+	defer server.Close()
+	apiClient := client.NewClientWithConfig(server.URL, "test-api-key")
+
+	outputDir := t.TempDir()
+	if err := downloadGlob(context.Background(), apiClient, "/photos/**/*.jpg", outputDir, client.DownloadOptions{}); err != nil {
+		t.Fatalf("downloadGlob() error = %v", err)
+	}
+	// Encrypted files are saved without their suffix
+	if err := downloadGlob(context.Background(), apiClient, "/photos/*.enc", outputDir, client.DownloadOptions{}); err != nil {
+		t.Fatalf("downloadGlob() error = %v", err)
+	}
+
+	want := map[string]string{"a.jpg": "file-1", "2023/b.jpg": "file-2", "d.pdf": "file-4"}
+	for rel, id := range want {
+		data, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(rel)))
+		if err != nil {
+			t.Errorf("Expected %s to be downloaded: %v", rel, err)
+			continue
+		}
+		if string(data) != id {
+			t.Errorf("%s content = %q, want %q", rel, data, id)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "2023", "c.png")); !os.IsNotExist(err) {
+		t.Errorf("Non-matching file was downloaded")
+	}
+}
+
+func TestParseRemoteTarget(t *testing.T) {
+	tests := []struct {
+		destination string
+		want        remoteTarget
+	}{
+		{"/archive/", remoteTarget{Folder: "/archive"}},
+		{"archive/2024/", remoteTarget{Folder: "/archive/2024"}},
+		{"/", remoteTarget{Folder: "/"}},
+		{"/archive/final.pdf", remoteTarget{Folder: "/archive", Filename: "final.pdf"}},
+		{"final.pdf", remoteTarget{Folder: "/", Filename: "final.pdf"}},
+	}
+	for _, tt := range tests {
+		got, err := parseRemoteTarget(tt.destination)
+		if err != nil {
+			t.Errorf("parseRemoteTarget(%q) error = %v", tt.destination, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseRemoteTarget(%q) = %+v, want %+v", tt.destination, got, tt.want)
+		}
+	}
+
+	for _, destination := range []string{"", "/../x/", "/a/../b.txt"} {
+		if _, err := parseRemoteTarget(destination); err == nil {
+			t.Errorf("parseRemoteTarget(%q) expected error, got nil", destination)
+		}
+	}
+
+	if got := (remoteTarget{Folder: "/"}).path(&file.FileResponse{Filename: "a.jpg"}); got != "/a.jpg" {
+		t.Errorf("path() = %q, want /a.jpg", got)
+	}
+}
+
+func TestDownloadGlob_RejectsPathsOutsideOutputDir(t *testing.T) {
+	photos := "/photos"
+	files := []file.FileResponse{
+		{ID: "file-1", Filename: "a.jpg", FolderPath: &photos},
+		{ID: "file-2", Filename: "../../escape.jpg", FolderPath: &photos},
+	}
+	server := testutil.SetupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/folders":
+			testutil.JSONResponse(w, http.StatusOK, []file.FolderResponse{})
+		case r.URL.Path == "/api/files":
+			testutil.JSONResponse(w, http.StatusOK, file.PageResponse{
+				Content: files, TotalElements: int64(len(files)), TotalPages: 1, Last: true,
+			})
+		case strings.HasSuffix(r.URL.Path, "/download"):
+			w.Write([]byte("content"))
+		default:
+			testutil.ErrorResponse(w, http.StatusNotFound, "Not found")
+		}
+	})
+	defer server.Close()
+	apiClient := client.NewClientWithConfig(server.URL, "test-api-key")
+
+	root := t.TempDir()
+	outputDir := filepath.Join(root, "a", "b")
+	err := downloadGlob(context.Background(), apiClient, "/photos/**/*.jpg", outputDir, client.DownloadOptions{})
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 files could not be downloaded") {
+		t.Errorf("downloadGlob() error = %v, want the escaping file to fail", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "a.jpg")); err != nil {
+		t.Errorf("Expected a.jpg to be downloaded: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "escape.jpg")); !os.IsNotExist(err) {
+		t.Errorf("File was written outside the output directory")
+	}
+}
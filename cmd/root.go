@@ -16,18 +16,33 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"os"
+	"os/signal"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/client"
 	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/config"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/util"
 )
 
 var (
-	apiURL     string
-	cfgFile    string
-	verbose    bool
-	jsonOutput bool
+	apiURL      string
+	cfgFile     string
+	profile     string
+	verbose     bool
+	output      string
+	query       string
+	jsonAlias   bool
+	concurrency int
+	retries     int
+	retryDelay  time.Duration
+	timeout     time.Duration
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -37,9 +52,10 @@ var rootCmd = &cobra.Command{
 	Long: `Cloud Storage API CLI is a command-line tool for interacting with the Cloud Storage API.
 
 It provides commands for:
-  - Authentication (login, register, logout)
+  - Authentication (login, logout, status)
   - File operations (upload, download, list, search, update, delete, info)
   - Folder management (create, list, delete)
+  - Folder synchronization (push, pull)
   - API key management
   - Batch job status
 
@@ -54,9 +70,114 @@ Examples:
   cloud-storage-api-cli file list --page 0 --size 20
 
   # Download a file
-  cloud-storage-api-cli file download <file-id> --output ./downloaded.pdf
+  cloud-storage-api-cli file download <file-id> --dest ./downloaded.pdf
+
+  # Use a different profile for one command
+  cloud-storage-api-cli file list --profile work
+
+  # Print machine-readable output
+  cloud-storage-api-cli file list -o json
+  cloud-storage-api-cli file list -o 'template={{.filename}} {{.fileSize}}'
+
+  # Filter output with a JMESPath query
+  cloud-storage-api-cli file list --all --query 'content[?fileSize > ` + "`1000000`" + `].id'
+
+  # Talk to a different server
+  cloud-storage-api-cli file list --api-url https://staging.example.com
 
 For more information, use 'cloud-storage-api-cli <command> --help'`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// --profile selects the profile for this invocation only
+		if profile != "" {
+			if err := config.ValidateProfileName(strings.ToLower(profile)); err != nil {
+				return err
+			}
+			config.SetProfileOverride(profile)
+		}
+		// --api-url overrides CLOUD_STORAGE_API_URL, the profile and the build-time URL
+		if cmd.Flags().Changed("api-url") {
+			if err := config.SetAPIURLOverride(apiURL); err != nil {
+				return fmt.Errorf("invalid --api-url: %w", err)
+			}
+		}
+		// --json is kept as an alias for --output json. Listings that walk every
+		// page have always streamed it as NDJSON, one item per line.
+		streamOutput := output
+		if jsonAlias && !cmd.Root().PersistentFlags().Changed("output") {
+			output, streamOutput = util.FormatJSON, util.FormatNDJSON
+		}
+		var err error
+		if outputFormatter, err = newOutputFormatter(output); err != nil {
+			// -o used to set the destination of file download, which is now --dest
+			if cmd.Flags().Lookup("dest") != nil {
+				return fmt.Errorf("%w; use --dest for the download location", err)
+			}
+			return err
+		}
+		streamFormatter, err = newOutputFormatter(streamOutput)
+		return err
+	},
+}
+
+// outputFormatter renders command results in the --output format
+var outputFormatter util.Formatter
+
+// streamFormatter renders listings that are streamed item by item
+// It differs from outputFormatter only for --json, which streams NDJSON.
+var streamFormatter util.Formatter
+
+// newOutputFormatter returns the formatter for an output format, applying --query
+func newOutputFormatter(format string) (util.Formatter, error) {
+	formatter, err := util.NewFormatter(format)
+	if err != nil {
+		return nil, err
+	}
+	// --query filters the JSON form of every result before it is rendered
+	if query != "" {
+		return util.NewQueryFormatter(formatter, util.IsHumanFormat(format), query)
+	}
+	return formatter, nil
+}
+
+// currentFormatter returns the selected output formatter, defaulting to table
+func currentFormatter() util.Formatter {
+	if outputFormatter == nil {
+		outputFormatter, _ = util.NewFormatter(util.FormatTable)
+	}
+	return outputFormatter
+}
+
+// currentStreamFormatter returns the formatter for streamed listings
+func currentStreamFormatter() util.Formatter {
+	if streamFormatter == nil {
+		return currentFormatter()
+	}
+	return streamFormatter
+}
+
+// humanOutput reports whether human-readable output (table or wide) is
+// selected and no --query reshapes it
+func humanOutput() bool {
+	return util.IsHumanFormat(output) && query == ""
+}
+
+// printResult renders a command result to stdout in the --output format
+func printResult(result *util.Result) error {
+	return currentFormatter().Print(os.Stdout, result)
+}
+
+// exitError is returned by commands that need a specific process exit code
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -67,18 +188,56 @@ func Execute() {
 		fmt.Fprintf(os.Stderr, "Warning: Failed to initialize config: %v\n", err)
 	}
 
-	// API URL is hardcoded at compile time - always use the build-time value
-	apiURL = config.GetAPIURL()
-
-	err := rootCmd.Execute()
+	// Cancel the command context on Ctrl-C so in-flight transfers can stop cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
 	if err != nil {
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		os.Exit(1)
 	}
 }
 
+// newAPIClient creates an API client from the stored configuration and global flags
+func newAPIClient() (*client.Client, error) {
+	apiClient, err := client.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	configureClient(apiClient)
+	return apiClient, nil
+}
+
+// configureClient applies the global flags (retries, timeouts, verbose logging) to an API client
+func configureClient(apiClient *client.Client) {
+	apiClient.Timeout = timeout
+	// Transfers may legitimately run for a long time, so they are only
+	// bounded when --timeout is given explicitly
+	if rootCmd.PersistentFlags().Changed("timeout") {
+		apiClient.TransferTimeout = timeout
+	}
+	apiClient.Retry.MaxAttempts = retries + 1
+	apiClient.Retry.BaseDelay = retryDelay
+	if verbose {
+		apiClient.Logger = log.New(os.Stderr, "[verbose] ", log.Ltime)
+	}
+}
+
 func init() {
 	// Persistent flags available to all subcommands
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.cloud-storage-cli/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "", "API base URL (default is $CLOUD_STORAGE_API_URL, the profile's api_url, or the compiled-in URL)")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "configuration profile to use (default is the current profile, or $CLOUD_STORAGE_PROFILE)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
-	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "output in JSON format")
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", util.FormatTable, "output format: table, wide, json, ndjson, csv, yaml or template=<go-template>")
+	rootCmd.PersistentFlags().StringVar(&query, "query", "", "JMESPath `expression` applied to the output before it is rendered (e.g. 'content[].id'); listings are collected in full instead of streamed")
+	// --json stays a silent alias so scripts that check stderr keep working
+	rootCmd.PersistentFlags().BoolVar(&jsonAlias, "json", false, "output in JSON format (deprecated: use --output json; listings that walk every page stream NDJSON)")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", client.DefaultConcurrency, "number of files transferred in parallel")
+	rootCmd.PersistentFlags().IntVar(&retries, "retries", client.DefaultMaxAttempts-1, "number of times a failed request is retried (0 disables retries)")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", client.DefaultTimeout, "timeout for API requests, 0 for no limit (uploads and downloads are unlimited unless set)")
+	rootCmd.PersistentFlags().DurationVar(&retryDelay, "retry-delay", client.DefaultRetryBaseDelay, "base delay between retries (doubles on each attempt)")
 }
@@ -0,0 +1,518 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/client"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/util"
+)
+
+// Sync action operations
+const (
+	syncUpload       = "upload"
+	syncDownload     = "download"
+	syncDeleteRemote = "delete-remote"
+	syncDeleteLocal  = "delete-local"
+)
+
+// localEntry is a local file taking part in a sync
+type localEntry struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// syncAction is a single change needed to bring the destination in line with the source
+type syncAction struct {
+	Op         string             `json:"op"`
+	RelPath    string             `json:"relPath"`
+	LocalPath  string             `json:"localPath,omitempty"`
+	FolderPath string             `json:"folderPath,omitempty"`
+	Size       int64              `json:"size"`
+	Reason     string             `json:"reason"`
+	Remote     *file.FileResponse `json:"-"`
+	Success    bool               `json:"success"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// syncReport is the final change report of a sync run
+type syncReport struct {
+	Direction  string       `json:"direction"`
+	Local      string       `json:"local"`
+	Remote     string       `json:"remote"`
+	DryRun     bool         `json:"dryRun"`
+	Uploaded   int          `json:"uploaded"`
+	Downloaded int          `json:"downloaded"`
+	Deleted    int          `json:"deleted"`
+	Unchanged  int          `json:"unchanged"`
+	Failed     int          `json:"failed"`
+	Actions    []syncAction `json:"actions"`
+}
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Synchronize a local directory with a remote folder",
+	Long: `Synchronize a local directory with a remote folder.
+
+Files are compared by relative path, size and modification time. Only files
+that are missing or differ are transferred.
+
+Available commands:
+  push - Upload local changes to a remote folder
+  pull - Download remote changes to a local directory`,
+}
+
+// syncPushCmd represents the sync push command
+var syncPushCmd = &cobra.Command{
+	Use:   "push <local-dir> <remote-folder>",
+	Short: "Upload local changes to a remote folder",
+	Long: `Upload files that are new or changed in a local directory to a remote folder.
+
+A file is uploaded when it does not exist remotely, its size differs, or it was
+modified locally after the remote copy was last updated. Changed remote files
+are replaced. With --delete, remote files that no longer exist locally are deleted.
+
+Examples:
+  cloud-storage-api-cli sync push ./photos /photos
+  cloud-storage-api-cli sync push ./photos /photos --delete --dry-run`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSync(cmd, "push", args[0], args[1])
+	},
+}
+
+// syncPullCmd represents the sync pull command
+var syncPullCmd = &cobra.Command{
+	Use:   "pull <remote-folder> <local-dir>",
+	Short: "Download remote changes to a local directory",
+	Long: `Download files that are new or changed in a remote folder to a local directory.
+
+A file is downloaded when it does not exist locally, its size differs, or the
+remote copy was updated after the local file was modified. Downloaded files take
+the remote modification time. With --delete, local files that no longer exist
+remotely are deleted.
+
+Examples:
+  cloud-storage-api-cli sync pull /photos ./photos
+  cloud-storage-api-cli sync pull /photos ./photos --delete --dry-run`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSync(cmd, "pull", args[1], args[0])
+	},
+}
+
+// runSync compares localDir with remoteFolder and applies the changes for direction
+func runSync(cmd *cobra.Command, direction, localDir, remoteFolder string) error {
+	deleteExtra, _ := cmd.Flags().GetBool("delete")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	// Validate remote folder path
+	if err := util.ValidatePath(remoteFolder); err != nil {
+		return fmt.Errorf("invalid remote folder: %w", err)
+	}
+	if remoteFolder != "/" {
+		remoteFolder = strings.TrimSuffix(remoteFolder, "/")
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+
+	// Collect local files; a missing directory is only acceptable when pulling
+	local, err := scanLocalDir(localDir)
+	if err != nil {
+		if !(direction == "pull" && os.IsNotExist(err)) {
+			return err
+		}
+		local = map[string]localEntry{}
+	}
+
+	// Create API client
+	apiClient, err := newAPIClient()
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	// Collect remote files
+	remoteFiles, err := apiClient.ListFolderTree(cmd.Context(), remoteFolder)
+	if err != nil {
+		return fmt.Errorf("failed to list remote files: %w", err)
+	}
+	remote := indexRemoteFiles(remoteFiles, remoteFolder)
+
+	var actions []syncAction
+	var unchanged int
+	if direction == "push" {
+		actions, unchanged = planPush(local, remote, remoteFolder, deleteExtra)
+	} else {
+		actions, unchanged = planPull(local, remote, localDir, deleteExtra)
+	}
+
+	report := syncReport{
+		Direction: direction,
+		Local:     localDir,
+		Remote:    remoteFolder,
+		DryRun:    dryRun,
+		Unchanged: unchanged,
+		Actions:   actions,
+	}
+	if report.Actions == nil {
+		report.Actions = []syncAction{}
+	}
+
+	if !dryRun {
+		applySyncActions(cmd.Context(), apiClient, report.Actions)
+	}
+	for _, action := range report.Actions {
+		if !dryRun && !action.Success {
+			report.Failed++
+			continue
+		}
+		switch action.Op {
+		case syncUpload:
+			report.Uploaded++
+		case syncDownload:
+			report.Downloaded++
+		default:
+			report.Deleted++
+		}
+	}
+
+	err = printResult(&util.Result{
+		Data:  report,
+		Items: report.Actions,
+		Text:  func() { displaySyncReport(&report) },
+	})
+	if err != nil {
+		return err
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d changes failed", report.Failed, len(report.Actions))
+	}
+	return nil
+}
+
+// scanLocalDir returns the regular files under root keyed by slash-separated relative path
+func scanLocalDir(root string) (map[string]localEntry, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory: %s", root)
+	}
+
+	entries := map[string]localEntry{}
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		entries[filepath.ToSlash(rel)] = localEntry{Path: p, Size: info.Size(), ModTime: info.ModTime()}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+	return entries, nil
+}
+
+// indexRemoteFiles keys remote files by their path relative to root
+// When several files share a path, the most recently updated one wins.
+func indexRemoteFiles(files []file.FileResponse, root string) map[string]file.FileResponse {
+	index := map[string]file.FileResponse{}
+	for _, f := range files {
+		folder := client.FileFolder(&f)
+		var rel string
+		switch {
+		case folder == root:
+			rel = f.Filename
+		case client.IsUnderFolder(folder, root):
+			rel = strings.TrimPrefix(strings.TrimPrefix(folder, root), "/") + "/" + f.Filename
+		default:
+			continue
+		}
+		if existing, ok := index[rel]; ok && existing.UpdatedAt.After(f.UpdatedAt) {
+			continue
+		}
+		index[rel] = f
+	}
+	return index
+}
+
+// localPathUnder joins the slash-separated remote path rel onto the local directory dir
+// Remote names come from the server, so a path that would leave dir (through ".."
+// or an absolute path) is rejected instead of being written outside it.
+func localPathUnder(dir, rel string) (string, error) {
+	local := filepath.FromSlash(rel)
+	if !filepath.IsLocal(local) {
+		return "", fmt.Errorf("remote path %q would be saved outside %s", rel, dir)
+	}
+	return filepath.Join(dir, local), nil
+}
+
+// planPush returns the actions needed to make remote match local
+func planPush(local map[string]localEntry, remote map[string]file.FileResponse, remoteFolder string, deleteExtra bool) ([]syncAction, int) {
+	var actions []syncAction
+	unchanged := 0
+	for _, rel := range sortedKeys(local) {
+		entry := local[rel]
+		action := syncAction{
+			Op:         syncUpload,
+			RelPath:    rel,
+			LocalPath:  entry.Path,
+			FolderPath: remoteFolderFor(remoteFolder, path.Dir(rel)),
+			Size:       entry.Size,
+		}
+		if remoteFolder == "/" && path.Dir(rel) == "." {
+			action.FolderPath = ""
+		}
+
+		r, ok := remote[rel]
+		switch {
+		case !ok:
+			action.Reason = "new file"
+		case r.FileSize != entry.Size:
+			action.Reason = "size differs"
+		case entry.ModTime.Truncate(time.Second).After(r.UpdatedAt):
+			action.Reason = "modified locally"
+		default:
+			unchanged++
+			continue
+		}
+		if ok {
+			action.Remote = &r
+		}
+		actions = append(actions, action)
+	}
+
+	if deleteExtra {
+		for _, rel := range sortedKeys(remote) {
+			if _, ok := local[rel]; ok {
+				continue
+			}
+			r := remote[rel]
+			actions = append(actions, syncAction{
+				Op:         syncDeleteRemote,
+				RelPath:    rel,
+				FolderPath: client.FileFolder(&r),
+				Size:       r.FileSize,
+				Reason:     "not present locally",
+				Remote:     &r,
+			})
+		}
+	}
+	return actions, unchanged
+}
+
+// planPull returns the actions needed to make local match remote
+func planPull(local map[string]localEntry, remote map[string]file.FileResponse, localDir string, deleteExtra bool) ([]syncAction, int) {
+	var actions []syncAction
+	unchanged := 0
+	for _, rel := range sortedKeys(remote) {
+		r := remote[rel]
+		action := syncAction{
+			Op:         syncDownload,
+			RelPath:    rel,
+			FolderPath: client.FileFolder(&r),
+			Size:       r.FileSize,
+			Remote:     &r,
+		}
+
+		// A remote path that would land outside localDir is reported, never written
+		localPath, err := localPathUnder(localDir, rel)
+		if err != nil {
+			action.Reason = "unsafe path"
+			action.Error = err.Error()
+			actions = append(actions, action)
+			continue
+		}
+		action.LocalPath = localPath
+
+		entry, ok := local[rel]
+		switch {
+		case !ok:
+			action.Reason = "new file"
+		case entry.Size != r.FileSize:
+			action.Reason = "size differs"
+		case r.UpdatedAt.Truncate(time.Second).After(entry.ModTime):
+			action.Reason = "updated remotely"
+		default:
+			unchanged++
+			continue
+		}
+		actions = append(actions, action)
+	}
+
+	if deleteExtra {
+		for _, rel := range sortedKeys(local) {
+			if _, ok := remote[rel]; ok {
+				continue
+			}
+			entry := local[rel]
+			actions = append(actions, syncAction{
+				Op:        syncDeleteLocal,
+				RelPath:   rel,
+				LocalPath: entry.Path,
+				Size:      entry.Size,
+				Reason:    "not present remotely",
+			})
+		}
+	}
+	return actions, unchanged
+}
+
+// applySyncActions runs the planned actions in parallel and records their outcome
+func applySyncActions(ctx context.Context, apiClient *client.Client, actions []syncAction) {
+	jobs := make([]client.TransferJob, len(actions))
+	for i := range actions {
+		action := &actions[i]
+		jobs[i] = client.TransferJob{
+			Name: action.RelPath,
+			Run: func(ctx context.Context, c *client.Client) error {
+				// Actions rejected while planning fail without being run
+				if action.Error != "" {
+					return errors.New(action.Error)
+				}
+				return applySyncAction(ctx, c, action)
+			},
+		}
+		switch action.Op {
+		case syncUpload:
+			jobs[i].Size = apiClient.UploadSize(action.Size)
+		case syncDownload:
+			jobs[i].Size = action.Size
+		}
+	}
+
+	scheduler := client.NewTransferScheduler(apiClient, concurrency)
+	scheduler.SetDescription("Syncing")
+	for i, res := range scheduler.Run(ctx, jobs) {
+		if res.Err != nil {
+			actions[i].Error = res.Err.Error()
+		} else {
+			actions[i].Success = true
+		}
+	}
+}
+
+// applySyncAction performs a single sync action
+func applySyncAction(ctx context.Context, c *client.Client, action *syncAction) error {
+	switch action.Op {
+	case syncUpload:
+		var fileResp file.FileResponse
+		if err := c.UploadFileContext(ctx, "/api/files/upload", action.LocalPath, action.FolderPath, "", &fileResp); err != nil {
+			return err
+		}
+		// Replace the previous remote copy only once the new one is stored
+		if action.Remote != nil {
+			if err := c.DeleteContext(ctx, fmt.Sprintf("/api/files/%s", action.Remote.ID)); err != nil {
+				return fmt.Errorf("uploaded but failed to remove previous version: %w", err)
+			}
+		}
+		return nil
+	case syncDownload:
+		path := fmt.Sprintf("/api/files/%s/download", action.Remote.ID)
+		if _, err := c.DownloadFileContext(ctx, path, action.LocalPath, client.DownloadOptions{}); err != nil {
+			return err
+		}
+		// Keep the remote timestamp so the next sync sees the file as unchanged
+		return os.Chtimes(action.LocalPath, time.Now(), action.Remote.UpdatedAt)
+	case syncDeleteRemote:
+		return c.DeleteContext(ctx, fmt.Sprintf("/api/files/%s", action.Remote.ID))
+	case syncDeleteLocal:
+		return os.Remove(action.LocalPath)
+	default:
+		return fmt.Errorf("unknown sync action: %s", action.Op)
+	}
+}
+
+// displaySyncReport prints the change report of a sync run
+func displaySyncReport(report *syncReport) {
+	if report.DryRun {
+		fmt.Println("Dry run - no changes were made.")
+	}
+	for _, action := range report.Actions {
+		status := "✓"
+		switch {
+		case report.DryRun:
+			status = "•"
+		case !action.Success:
+			status = "✗"
+		}
+		fmt.Printf("%s %-13s %s (%s)", status, action.Op, action.RelPath, action.Reason)
+		if action.Error != "" {
+			fmt.Printf(": %s", action.Error)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println()
+	fmt.Printf("Sync Summary (%s)\n", report.Direction)
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Local:       %s\n", report.Local)
+	fmt.Printf("Remote:      %s\n", report.Remote)
+	fmt.Printf("Uploaded:    %d\n", report.Uploaded)
+	fmt.Printf("Downloaded:  %d\n", report.Downloaded)
+	fmt.Printf("Deleted:     %d\n", report.Deleted)
+	fmt.Printf("Unchanged:   %d\n", report.Unchanged)
+	fmt.Printf("Failed:      %d\n", report.Failed)
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.AddCommand(syncPushCmd)
+	syncCmd.AddCommand(syncPullCmd)
+
+	for _, c := range []*cobra.Command{syncPushCmd, syncPullCmd} {
+		c.Flags().Bool("delete", false, "Delete files at the destination that no longer exist at the source")
+		c.Flags().Bool("dry-run", false, "Show what would change without transferring or deleting anything")
+	}
+}
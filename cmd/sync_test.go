@@ -0,0 +1,167 @@
+//go:build integration
+
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/client"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+)
+
+func remoteFile(id, folder, name string, size int64, updated time.Time) file.FileResponse {
+	return file.FileResponse{ID: id, Filename: name, FolderPath: &folder, FileSize: size, UpdatedAt: updated}
+}
+
+func TestIndexRemoteFiles(t *testing.T) {
+	now := time.Now()
+	files := []file.FileResponse{
+		remoteFile("1", "/photos", "a.jpg", 10, now),
+		remoteFile("2", "/photos/2024", "b.jpg", 20, now),
+		remoteFile("3", "/photos-old", "c.jpg", 30, now),
+	}
+
+	index := indexRemoteFiles(files, "/photos")
+	if len(index) != 2 {
+		t.Fatalf("Expected 2 indexed files, got %d", len(index))
+	}
+	if index["a.jpg"].ID != "1" || index["2024/b.jpg"].ID != "2" {
+		t.Errorf("Unexpected index: %+v", index)
+	}
+}
+
+func TestPlanPush(t *testing.T) {
+	old := time.Now().Add(-time.Hour)
+	now := time.Now()
+	local := map[string]localEntry{
+		"same.txt":    {Path: "/l/same.txt", Size: 5, ModTime: old},
+		"bigger.txt":  {Path: "/l/bigger.txt", Size: 9, ModTime: old},
+		"touched.txt": {Path: "/l/touched.txt", Size: 5, ModTime: now},
+		"sub/new.txt": {Path: "/l/sub/new.txt", Size: 1, ModTime: now},
+	}
+	remote := map[string]file.FileResponse{
+		"same.txt":    remoteFile("1", "/dst", "same.txt", 5, now),
+		"bigger.txt":  remoteFile("2", "/dst", "bigger.txt", 5, now),
+		"touched.txt": remoteFile("3", "/dst", "touched.txt", 5, old),
+		"gone.txt":    remoteFile("4", "/dst", "gone.txt", 5, now),
+	}
+
+	actions, unchanged := planPush(local, remote, "/dst", true)
+	if unchanged != 1 {
+		t.Errorf("Expected 1 unchanged file, got %d", unchanged)
+	}
+
+	ops := map[string]string{}
+	for _, a := range actions {
+		ops[a.RelPath] = a.Op
+		if a.RelPath == "sub/new.txt" && a.FolderPath != "/dst/sub" {
+			t.Errorf("Expected sub/new.txt to go to /dst/sub, got %q", a.FolderPath)
+		}
+	}
+	expected := map[string]string{
+		"bigger.txt":  syncUpload,
+		"touched.txt": syncUpload,
+		"sub/new.txt": syncUpload,
+		"gone.txt":    syncDeleteRemote,
+	}
+	for rel, op := range expected {
+		if ops[rel] != op {
+			t.Errorf("Expected %s for %s, got %q", op, rel, ops[rel])
+		}
+	}
+	if len(actions) != len(expected) {
+		t.Errorf("Expected %d actions, got %d", len(expected), len(actions))
+	}
+}
+
+func TestPlanPull(t *testing.T) {
+	old := time.Now().Add(-time.Hour)
+	now := time.Now()
+	local := map[string]localEntry{
+		"same.txt":  {Path: "/l/same.txt", Size: 5, ModTime: now},
+		"stale.txt": {Path: "/l/stale.txt", Size: 5, ModTime: old},
+		"extra.txt": {Path: "/l/extra.txt", Size: 5, ModTime: old},
+	}
+	remote := map[string]file.FileResponse{
+		"same.txt":    remoteFile("1", "/src", "same.txt", 5, old),
+		"stale.txt":   remoteFile("2", "/src", "stale.txt", 5, now),
+		"sub/new.txt": remoteFile("3", "/src/sub", "new.txt", 5, now),
+	}
+
+	// Without --delete, extra local files are left alone
+	actions, unchanged := planPull(local, remote, "/l", false)
+	if unchanged != 1 || len(actions) != 2 {
+		t.Fatalf("Expected 2 downloads and 1 unchanged, got %d actions and %d unchanged", len(actions), unchanged)
+	}
+	for _, a := range actions {
+		if a.Op != syncDownload {
+			t.Errorf("Expected only downloads, got %s for %s", a.Op, a.RelPath)
+		}
+	}
+
+	actions, _ = planPull(local, remote, "/l", true)
+	last := actions[len(actions)-1]
+	if last.Op != syncDeleteLocal || last.RelPath != "extra.txt" {
+		t.Errorf("Expected extra.txt to be deleted locally, got %+v", last)
+	}
+}
+
+func TestPlanPull_RejectsPathsOutsideLocalDir(t *testing.T) {
+	now := time.Now()
+	localDir := t.TempDir()
+	files := []file.FileResponse{
+		remoteFile("1", "/src", "../../escape.txt", 5, now),
+		remoteFile("2", "/src", "/etc/passwd", 5, now),
+		remoteFile("3", "/src/sub", "ok.txt", 5, now),
+	}
+
+	actions, _ := planPull(map[string]localEntry{}, indexRemoteFiles(files, "/src"), localDir, false)
+	if len(actions) != 3 {
+		t.Fatalf("Expected 3 actions, got %d", len(actions))
+	}
+	for _, a := range actions {
+		unsafe := a.Remote.ID != "3"
+		if unsafe && (a.Error == "" || a.LocalPath != "") {
+			t.Errorf("Expected %s to be rejected, got %+v", a.RelPath, a)
+		}
+		if !unsafe && (a.Error != "" || a.LocalPath != filepath.Join(localDir, "sub", "ok.txt")) {
+			t.Errorf("Expected %s to be downloaded into %s, got %+v", a.RelPath, localDir, a)
+		}
+	}
+
+	// Rejected actions fail when applied, without reaching the server
+	rejected := actions[:0]
+	for _, a := range actions {
+		if a.Error != "" {
+			rejected = append(rejected, a)
+		}
+	}
+	apiClient := client.NewClientWithConfig("http://127.0.0.1:0", "test-api-key")
+	apiClient.Progress = io.Discard
+	applySyncActions(context.Background(), apiClient, rejected)
+	for _, a := range rejected {
+		if a.Success || a.Error == "" {
+			t.Errorf("Expected %s to fail, got %+v", a.RelPath, a)
+		}
+	}
+}
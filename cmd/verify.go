@@ -0,0 +1,212 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/client"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/envelope"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/util"
+)
+
+// fileVerifyCmd represents the file verify command
+var fileVerifyCmd = &cobra.Command{
+	Use:   "verify <local-file> <file-id-or-path>",
+	Short: "Check that a local file matches a stored file",
+	Long: `Check that a local file has the same content as a file in cloud storage.
+
+The sizes are compared first. If the server stores a SHA-256 checksum for the
+file, the local file's SHA-256 is compared against it; otherwise the remote file
+is streamed and hashed without being saved.
+
+A file uploaded with --encrypt is downloaded and decrypted in memory, and the
+SHA-256 of its plaintext is compared with the local file. Other files named
+*.enc are downloaded and compared as they are stored.
+
+The command exits with an error if the files differ.
+
+Examples:
+  cloud-storage-api-cli file verify ./report.pdf /documents/report.pdf
+  cloud-storage-api-cli file verify ./photo.jpg 550e8400-e29b-41d4-a716-446655440000
+  cloud-storage-api-cli file verify ./contract.pdf /legal/contract.pdf.enc`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		localPath, remote := args[0], args[1]
+
+		info, err := os.Stat(localPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("file not found: %s", localPath)
+			}
+			return fmt.Errorf("failed to access file: %w", err)
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("not a regular file: %s", localPath)
+		}
+
+		apiClient, err := newAPIClient()
+		if err != nil {
+			return fmt.Errorf("failed to create API client: %w", err)
+		}
+		if err := configureEncryption(cmd, apiClient, false); err != nil {
+			return err
+		}
+
+		result, err := verifyFile(cmd.Context(), apiClient, localPath, remote)
+		if err != nil {
+			return err
+		}
+
+		err = printResult(&util.Result{
+			Data: result,
+			Text: func() { displayVerifyResult(result) },
+		})
+		if err != nil {
+			return err
+		}
+		if !result.Match {
+			return fmt.Errorf("%s does not match %s", localPath, result.Remote)
+		}
+		return nil
+	},
+}
+
+// verifyResult is the outcome of comparing a local file with a stored one
+type verifyResult struct {
+	Local        string `json:"local"`
+	Remote       string `json:"remote"`
+	ID           string `json:"id"`
+	LocalSize    int64  `json:"localSize"`
+	RemoteSize   int64  `json:"remoteSize"`
+	LocalSHA256  string `json:"localSha256"`
+	RemoteSHA256 string `json:"remoteSha256,omitempty"`
+	// Method is how the remote content was checked: "size", "checksum", "download" or "decrypt"
+	Method string `json:"method"`
+	Match  bool   `json:"match"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// verifyFile compares the local file at localPath with the stored file addressed by remote
+// A difference is reported in the result; errors are only returned when the
+// comparison itself could not be made.
+func verifyFile(ctx context.Context, apiClient *client.Client, localPath, remote string) (*verifyResult, error) {
+	f, err := apiClient.ResolveFile(ctx, remote)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := client.FileSHA256(localPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &verifyResult{
+		Local:       localPath,
+		Remote:      client.RemoteFilePath(f),
+		ID:          f.ID,
+		LocalSize:   digest.Size,
+		RemoteSize:  f.FileSize,
+		LocalSHA256: digest.SHA256,
+		Method:      "checksum",
+	}
+	if envelope.IsEncryptedName(f.Filename) {
+		return verifyEncrypted(ctx, apiClient, f, result)
+	}
+	if client.NormalizeChecksum(f.Checksum) == "" {
+		result.Method = "download"
+	}
+	if f.FileSize != digest.Size {
+		result.Method = "size"
+		result.Reason = fmt.Sprintf("sizes differ (%d vs %d bytes)", digest.Size, f.FileSize)
+		return result, nil
+	}
+
+	err = apiClient.VerifyRemote(ctx, f, digest)
+	var mismatch *client.ChecksumMismatchError
+	switch {
+	case errors.As(err, &mismatch):
+		result.RemoteSHA256 = mismatch.Expected
+		result.Reason = "checksums differ"
+	case err != nil:
+		return nil, err
+	default:
+		result.RemoteSHA256 = digest.Expected
+		result.Match = true
+	}
+	return result, nil
+}
+
+// verifyEncrypted completes result for a file with an encrypted name by downloading it
+// Only a file that starts with an envelope header is decrypted (asking for the
+// passphrase if needed), and the SHA-256 of its plaintext compared; any other
+// file is compared as stored, since its name alone does not make it encrypted.
+func verifyEncrypted(ctx context.Context, apiClient *client.Client, f *file.FileResponse, result *verifyResult) (*verifyResult, error) {
+	remote, err := apiClient.ContentSHA256(ctx, f.ID)
+	if err != nil {
+		return nil, err
+	}
+	result.Method = "download"
+	if remote.Decrypted {
+		result.Method = "decrypt"
+	}
+	result.RemoteSize = remote.Size
+	result.RemoteSHA256 = remote.SHA256
+	switch {
+	case remote.Size != result.LocalSize:
+		result.Reason = fmt.Sprintf("sizes differ (%d vs %d bytes)", result.LocalSize, remote.Size)
+	case remote.SHA256 != result.LocalSHA256:
+		result.Reason = "checksums differ"
+	default:
+		result.Match = true
+	}
+	return result, nil
+}
+
+// displayVerifyResult prints the comparison of a local and a stored file
+func displayVerifyResult(result *verifyResult) {
+	if result.Match {
+		fmt.Printf("✓ %s matches %s\n", result.Local, result.Remote)
+	} else {
+		fmt.Printf("✗ %s does not match %s: %s\n", result.Local, result.Remote, result.Reason)
+	}
+	fmt.Printf("File ID: %s\n", result.ID)
+	fmt.Printf("Local:  %s, SHA-256 %s\n", util.FormatFileSize(result.LocalSize), result.LocalSHA256)
+	if result.RemoteSHA256 != "" {
+		fmt.Printf("Remote: %s, SHA-256 %s\n", util.FormatFileSize(result.RemoteSize), result.RemoteSHA256)
+	} else {
+		fmt.Printf("Remote: %s\n", util.FormatFileSize(result.RemoteSize))
+	}
+	switch result.Method {
+	case "checksum":
+		fmt.Println("Checked against the checksum stored on the server.")
+	case "download":
+		fmt.Println("Checked by downloading and hashing the remote file.")
+	case "decrypt":
+		fmt.Println("Checked by downloading, decrypting and hashing the remote file.")
+	}
+}
+
+func init() {
+	fileCmd.AddCommand(fileVerifyCmd)
+
+	addEncryptionKeyFlag(fileVerifyCmd)
+}
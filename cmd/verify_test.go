@@ -0,0 +1,158 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/client"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/envelope"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/testutil"
+)
+
+func TestVerifyFile(t *testing.T) {
+	id := "550e8400-e29b-41d4-a716-446655440000"
+	content := "remote content"
+	docs := "/docs"
+
+	server := testutil.SetupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/files/" + id:
+			testutil.JSONResponse(w, http.StatusOK, file.FileResponse{
+				ID: id, Filename: "a.txt", FileSize: int64(len(content)), FolderPath: &docs,
+			})
+		case "/api/files/" + id + "/download":
+			io.WriteString(w, content)
+		default:
+			testutil.ErrorResponse(w, http.StatusNotFound, "Not found")
+		}
+	})
+	defer server.Close()
+	apiClient := client.NewClientWithConfig(server.URL, "test-api-key")
+	apiClient.Progress = io.Discard
+
+	tests := []struct {
+		name       string
+		local      string
+		wantMatch  bool
+		wantMethod string
+	}{
+		{name: "same content", local: content, wantMatch: true, wantMethod: "download"},
+		{name: "same size, different content", local: "remote c0ntent", wantMethod: "download"},
+		{name: "different size", local: "remote", wantMethod: "size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localPath := filepath.Join(t.TempDir(), "a.txt")
+			if err := os.WriteFile(localPath, []byte(tt.local), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := verifyFile(context.Background(), apiClient, localPath, id)
+			if err != nil {
+				t.Fatalf("verifyFile() error = %v", err)
+			}
+			if result.Match != tt.wantMatch || result.Method != tt.wantMethod {
+				t.Errorf("verifyFile() = match %v via %s, want match %v via %s",
+					result.Match, result.Method, tt.wantMatch, tt.wantMethod)
+			}
+			if result.Remote != "/docs/a.txt" {
+				t.Errorf("Remote = %q, want /docs/a.txt", result.Remote)
+			}
+		})
+	}
+}
+
+func TestVerifyFile_EncryptedName(t *testing.T) {
+	plaintext := "secret content"
+	key, err := envelope.NewRawKey(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypter, err := envelope.NewEncryptReader(strings.NewReader(plaintext), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := io.ReadAll(encrypter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// plain.enc is an ordinary file that only has an encrypted name
+	secretID, plainID := "550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440002"
+	stored := map[string][]byte{secretID: ciphertext, plainID: []byte(plaintext)}
+	names := map[string]string{secretID: "secret.enc", plainID: "plain.enc"}
+	server := testutil.SetupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/files/"), "/download")
+		content, ok := stored[id]
+		switch {
+		case !ok:
+			testutil.ErrorResponse(w, http.StatusNotFound, "Not found")
+		case strings.HasSuffix(r.URL.Path, "/download"):
+			w.Write(content)
+		default:
+			testutil.JSONResponse(w, http.StatusOK, file.FileResponse{
+				ID: id, Filename: names[id], FileSize: int64(len(content)),
+			})
+		}
+	})
+	defer server.Close()
+
+	localPath := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(localPath, []byte(plaintext), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id         string
+		wantMethod string
+	}{
+		{id: secretID, wantMethod: "decrypt"},
+		{id: plainID, wantMethod: "download"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantMethod, func(t *testing.T) {
+			apiClient := client.NewClientWithConfig(server.URL, "test-api-key")
+			apiClient.Progress = io.Discard
+			apiClient.Encryption = key
+			if tt.wantMethod == "download" {
+				// No passphrase may be asked for a file that is not an envelope
+				apiClient.Encryption = envelope.NewPassphraseKey(func(bool) (string, error) {
+					t.Error("Asked for a passphrase")
+					return "", errors.New("no passphrase")
+				})
+			}
+
+			result, err := verifyFile(context.Background(), apiClient, localPath, tt.id)
+			if err != nil {
+				t.Fatalf("verifyFile() error = %v", err)
+			}
+			if !result.Match || result.Method != tt.wantMethod {
+				t.Errorf("verifyFile() = match %v via %s (%s), want a match via %s",
+					result.Match, result.Method, result.Reason, tt.wantMethod)
+			}
+		})
+	}
+}
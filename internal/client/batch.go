@@ -0,0 +1,304 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+)
+
+// Batch job statuses reported by the API
+const (
+	BatchQueued     = "QUEUED"
+	BatchProcessing = "PROCESSING"
+	BatchCompleted  = "COMPLETED"
+	BatchFailed     = "FAILED"
+	BatchCancelled  = "CANCELLED"
+)
+
+const (
+	// DefaultPollInterval is the default delay between two batch status polls
+	DefaultPollInterval = 2 * time.Second
+	// DefaultPollMaxInterval caps the delay between polls while a job makes no progress
+	DefaultPollMaxInterval = 30 * time.Second
+)
+
+// BatchStatuses lists every batch job status, in lifecycle order
+var BatchStatuses = []string{BatchQueued, BatchProcessing, BatchCompleted, BatchFailed, BatchCancelled}
+
+// IsBatchFinished reports whether a batch job status is final
+func IsBatchFinished(status string) bool {
+	switch strings.ToUpper(status) {
+	case BatchCompleted, BatchFailed, BatchCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// PollPolicy controls how often a batch job is polled
+// The delay doubles on every poll that shows no change, up to MaxInterval,
+// and drops back to Interval as soon as the job moves again.
+type PollPolicy struct {
+	Interval    time.Duration
+	MaxInterval time.Duration
+}
+
+// DefaultPollPolicy returns the poll policy used when none is configured
+func DefaultPollPolicy() PollPolicy {
+	return PollPolicy{
+		Interval:    DefaultPollInterval,
+		MaxInterval: DefaultPollMaxInterval,
+	}
+}
+
+// next returns the delay before the poll that follows one waiting current
+func (p PollPolicy) next(current time.Duration, changed bool) time.Duration {
+	if changed || current <= 0 {
+		return p.Interval
+	}
+	next := current * 2
+	if p.MaxInterval > 0 && next > p.MaxInterval {
+		next = p.MaxInterval
+	}
+	return next
+}
+
+// GetBatchStatus fetches the current status of a batch job
+func (c *Client) GetBatchStatus(ctx context.Context, batchID string) (*file.BatchJobResponse, error) {
+	var batch file.BatchJobResponse
+	if err := c.GetContext(ctx, fmt.Sprintf("/api/batches/%s/status", batchID), &batch); err != nil {
+		return nil, fmt.Errorf("failed to get batch job status: %w", err)
+	}
+	return &batch, nil
+}
+
+// WaitForBatch polls a batch job until its status is final or ctx is done
+// onUpdate, if not nil, is called with every status fetched, including the last.
+// When ctx ends first, the last status seen is returned along with ctx's error.
+func (c *Client) WaitForBatch(ctx context.Context, batchID string, policy PollPolicy, onUpdate func(*file.BatchJobResponse)) (*file.BatchJobResponse, error) {
+	if policy.Interval <= 0 {
+		return nil, fmt.Errorf("poll interval must be positive")
+	}
+
+	var last *file.BatchJobResponse
+	var delay time.Duration
+	for {
+		batch, err := c.GetBatchStatus(ctx, batchID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return last, err
+		}
+		if onUpdate != nil {
+			onUpdate(batch)
+		}
+		if IsBatchFinished(batch.Status) {
+			return batch, nil
+		}
+
+		delay = policy.next(delay, batchChanged(last, batch))
+		last = batch
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// SubmitBatchDelete starts a batch job that deletes the files with the given IDs
+func (c *Client) SubmitBatchDelete(ctx context.Context, fileIDs []string) (*file.BatchJobResponse, error) {
+	var batch file.BatchJobResponse
+	if err := c.PostContext(ctx, "/api/batches/delete", file.BatchDeleteRequest{FileIDs: fileIDs}, &batch); err != nil {
+		return nil, fmt.Errorf("failed to submit batch delete: %w", err)
+	}
+	return &batch, nil
+}
+
+// SubmitBatchMove starts a batch job that moves the files with the given IDs into folderPath
+func (c *Client) SubmitBatchMove(ctx context.Context, fileIDs []string, folderPath string) (*file.BatchJobResponse, error) {
+	req := file.BatchMoveRequest{FileIDs: fileIDs, FolderPath: folderPath}
+	var batch file.BatchJobResponse
+	if err := c.PostContext(ctx, "/api/batches/move", req, &batch); err != nil {
+		return nil, fmt.Errorf("failed to submit batch move: %w", err)
+	}
+	return &batch, nil
+}
+
+// SubmitBatchUpload uploads local files as a single batch job
+// Every file is streamed as a "files" part of one multipart request, followed by
+// the optional folderPath field; the server stores them asynchronously.
+// The upload is additionally bounded by c.TransferTimeout when it is set.
+func (c *Client) SubmitBatchUpload(ctx context.Context, filePaths []string, folderPath string) (*file.BatchJobResponse, error) {
+	ctx, cancel := withTimeout(ctx, c.TransferTimeout)
+	defer cancel()
+
+	fullURL, err := c.buildURL("/api/batches/upload")
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, p := range filePaths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to access file: %w", err)
+		}
+		total += info.Size()
+	}
+	fields := uploadFields(folderPath, "")
+
+	progress, done := c.newProgress(total, "Uploading")
+	defer done()
+
+	// stopWriter closes the current pipe and waits for its body writer to exit
+	var pr *io.PipeReader
+	var writerDone chan struct{}
+	stopWriter := func() {
+		if pr != nil {
+			pr.Close()
+			<-writerDone
+		}
+	}
+	defer stopWriter()
+
+	// The request starts a server-side job, so it is sent only once: a retry
+	// after a lost response would upload every file again into a second job
+	resp, err := c.do(ctx, func(attempt int) (*http.Request, error) {
+		var pw *io.PipeWriter
+		pr, pw = io.Pipe()
+		writerDone = make(chan struct{})
+		writer := multipart.NewWriter(pw)
+
+		overhead, err := batchUploadOverhead(writer.Boundary(), filePaths, fields)
+		if err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, pr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Accept", "application/json")
+		req.ContentLength = overhead + total
+		c.setAuthHeaders(req)
+
+		finished := writerDone
+		go func() {
+			defer close(finished)
+			pw.CloseWithError(writeBatchUploadBody(writer, filePaths, progress, fields))
+		}()
+		return req, nil
+	}, false)
+	if err != nil {
+		return nil, fmt.Errorf("request failed [POST %s]: %w", fullURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, c.parseErrorResponse(resp, http.MethodPost, fullURL)
+	}
+
+	var batch file.BatchJobResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &batch, nil
+}
+
+// writeBatchUploadBody writes one "files" part per local file, then the form fields
+// A nil progress writer writes empty parts, which is used to measure the overhead.
+func writeBatchUploadBody(writer *multipart.Writer, filePaths []string, progress io.Writer, fields []formField) error {
+	for _, p := range filePaths {
+		part, err := writer.CreateFormFile("files", filepath.Base(p))
+		if err != nil {
+			return fmt.Errorf("failed to create form file field: %w", err)
+		}
+		if progress == nil {
+			continue
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		_, err = io.Copy(part, io.TeeReader(f, progress))
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to copy file content: %w", err)
+		}
+	}
+
+	for _, field := range fields {
+		if err := writer.WriteField(field.name, field.value); err != nil {
+			return fmt.Errorf("failed to write %s field: %w", field.name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+	return nil
+}
+
+// batchUploadOverhead returns the number of bytes a batch upload body adds around the file contents
+func batchUploadOverhead(boundary string, filePaths []string, fields []formField) (int64, error) {
+	var counter byteCounter
+	writer := multipart.NewWriter(&counter)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return 0, fmt.Errorf("failed to set multipart boundary: %w", err)
+	}
+	if err := writeBatchUploadBody(writer, filePaths, nil, fields); err != nil {
+		return 0, err
+	}
+	return counter.n, nil
+}
+
+// FailedBatchItems returns the items of a batch job that failed
+// Items are reported with the same FAILED status as jobs.
+func FailedBatchItems(batch *file.BatchJobResponse) []file.BatchItemResult {
+	var failed []file.BatchItemResult
+	for _, item := range batch.Items {
+		if strings.EqualFold(item.Status, BatchFailed) {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// batchChanged reports whether a job moved between two polls
+func batchChanged(before, after *file.BatchJobResponse) bool {
+	return before == nil ||
+		before.Status != after.Status ||
+		before.Progress != after.Progress ||
+		before.ProcessedItems != after.ProcessedItems ||
+		before.FailedItems != after.FailedItems
+}
@@ -0,0 +1,189 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+)
+
+// newBatchTestServer serves /api/batches/job-1/status, returning statuses in order
+// and repeating the last one once they run out
+func newBatchTestServer(t *testing.T, statuses ...file.BatchJobResponse) (*Client, *int32) {
+	t.Helper()
+	var polls int32
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/batches/job-1/status" {
+			t.Errorf("Unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		n := int(atomic.AddInt32(&polls, 1)) - 1
+		if n >= len(statuses) {
+			n = len(statuses) - 1
+		}
+		json.NewEncoder(w).Encode(statuses[n])
+	})
+	t.Cleanup(server.Close)
+	return NewClientWithConfig(server.URL, ""), &polls
+}
+
+func TestClient_WaitForBatch(t *testing.T) {
+	c, polls := newBatchTestServer(t,
+		file.BatchJobResponse{BatchID: "job-1", Status: BatchQueued},
+		file.BatchJobResponse{BatchID: "job-1", Status: BatchProcessing, Progress: 50},
+		file.BatchJobResponse{BatchID: "job-1", Status: BatchCompleted, Progress: 100},
+	)
+
+	var seen []string
+	policy := PollPolicy{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+	batch, err := c.WaitForBatch(context.Background(), "job-1", policy, func(b *file.BatchJobResponse) {
+		seen = append(seen, b.Status)
+	})
+	if err != nil {
+		t.Fatalf("WaitForBatch() error = %v", err)
+	}
+	if n := atomic.LoadInt32(polls); batch.Status != BatchCompleted || n != 3 {
+		t.Errorf("Expected COMPLETED after 3 polls, got %s after %d", batch.Status, n)
+	}
+	if len(seen) != 3 || seen[2] != BatchCompleted {
+		t.Errorf("onUpdate saw %v", seen)
+	}
+}
+
+func TestClient_WaitForBatch_Timeout(t *testing.T) {
+	c, _ := newBatchTestServer(t, file.BatchJobResponse{BatchID: "job-1", Status: BatchProcessing, Progress: 10})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	policy := PollPolicy{Interval: 5 * time.Millisecond, MaxInterval: 10 * time.Millisecond}
+	batch, err := c.WaitForBatch(ctx, "job-1", policy, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	}
+	if batch == nil || batch.Progress != 10 {
+		t.Errorf("Expected the last status seen, got %+v", batch)
+	}
+}
+
+func TestPollPolicy_Next(t *testing.T) {
+	p := PollPolicy{Interval: time.Second, MaxInterval: 5 * time.Second}
+	tests := []struct {
+		current time.Duration
+		changed bool
+		want    time.Duration
+	}{
+		{0, false, time.Second},
+		{time.Second, false, 2 * time.Second},
+		{4 * time.Second, false, 5 * time.Second},
+		{5 * time.Second, true, time.Second},
+	}
+	for _, tt := range tests {
+		if got := p.next(tt.current, tt.changed); got != tt.want {
+			t.Errorf("next(%s, %v) = %s, want %s", tt.current, tt.changed, got, tt.want)
+		}
+	}
+	if !IsBatchFinished("cancelled") || IsBatchFinished(BatchProcessing) {
+		t.Error("IsBatchFinished() classified statuses incorrectly")
+	}
+}
+
+func TestFailedBatchItems(t *testing.T) {
+	batch := &file.BatchJobResponse{Items: []file.BatchItemResult{
+		{ItemID: "a", Status: BatchCompleted},
+		{ItemID: "b", Status: "failed", ErrorMessage: "not found"},
+		{ItemID: "c", Status: BatchFailed},
+	}}
+	failed := FailedBatchItems(batch)
+	if len(failed) != 2 || failed[0].ItemID != "b" || failed[1].ItemID != "c" {
+		t.Errorf("FailedBatchItems() = %+v, want items b and c", failed)
+	}
+}
+
+func TestClient_SubmitBatchDelete(t *testing.T) {
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/batches/delete" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req file.BatchDeleteRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if !reflect.DeepEqual(req.FileIDs, []string{"a", "b"}) {
+			t.Errorf("Expected fileIds [a b], got %v", req.FileIDs)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(file.BatchJobResponse{BatchID: "job-1", Status: BatchQueued, StatusURL: "/api/batches/job-1/status"})
+	})
+	defer server.Close()
+
+	batch, err := NewClientWithConfig(server.URL, "").SubmitBatchDelete(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("SubmitBatchDelete() error = %v", err)
+	}
+	if batch.BatchID != "job-1" || batch.StatusURL == "" {
+		t.Errorf("Unexpected batch: %+v", batch)
+	}
+}
+
+func TestClient_SubmitBatchUpload(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")}
+	os.WriteFile(paths[0], []byte("first file"), 0644)
+	os.WriteFile(paths[1], []byte("second"), 0644)
+
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.ContentLength != int64(len(body)) {
+			t.Errorf("Content-Length %d does not match body size %d", r.ContentLength, len(body))
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("Failed to parse multipart form: %v", err)
+			return
+		}
+		files := r.MultipartForm.File["files"]
+		if len(files) != 2 || files[0].Filename != "a.txt" || files[1].Size != 6 {
+			t.Errorf("Unexpected file parts: %d", len(files))
+		}
+		if got := r.FormValue("folderPath"); got != "/inbox" {
+			t.Errorf("Expected folderPath /inbox, got %q", got)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(file.BatchJobResponse{BatchID: "job-2", TotalItems: 2})
+	})
+	defer server.Close()
+
+	c := NewClientWithConfig(server.URL, "")
+	c.Progress = io.Discard
+	batch, err := c.SubmitBatchUpload(context.Background(), paths, "/inbox")
+	if err != nil {
+		t.Fatalf("SubmitBatchUpload() error = %v", err)
+	}
+	if batch.BatchID != "job-2" {
+		t.Errorf("Expected job-2, got %+v", batch)
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/envelope"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+)
+
+// TransferDigest describes the data that went over the wire in one transfer
+type TransferDigest struct {
+	Size int64 `json:"size"`
+	// SHA256 is the hex-encoded SHA-256 of the transferred data
+	SHA256 string `json:"sha256"`
+	// Expected is the checksum the server (or caller) supplied, if any
+	Expected string `json:"expected,omitempty"`
+	// Verified is true when SHA256 was compared against Expected and matched.
+	// For a decrypted download it means the stored ciphertext was verified.
+	Verified bool `json:"verified"`
+	// Decrypted is true when the data was an encrypted envelope and the digest describes its plaintext
+	Decrypted bool `json:"decrypted,omitempty"`
+}
+
+// ChecksumMismatchError is returned when transferred data does not hash to the expected SHA-256
+type ChecksumMismatchError struct {
+	Name     string
+	Expected string
+	Actual   string
+}
+
+// Error implements the error interface
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum mismatch for %s: expected SHA-256 %s, got %s", e.Name, e.Expected, e.Actual)
+}
+
+// verify compares the digest against expected (any form accepted by NormalizeChecksum)
+// An empty or unrecognised expected value leaves the digest unverified.
+func (d *TransferDigest) verify(name, expected string) error {
+	d.Expected = NormalizeChecksum(expected)
+	if d.Expected == "" {
+		return nil
+	}
+	if d.Expected != d.SHA256 {
+		return &ChecksumMismatchError{Name: name, Expected: d.Expected, Actual: d.SHA256}
+	}
+	d.Verified = true
+	return nil
+}
+
+// NormalizeChecksum returns a SHA-256 checksum as lowercase hex
+// It accepts hex or base64 digests, optionally quoted or prefixed with "sha256:"
+// or "sha-256=". Anything that is not a SHA-256 digest (e.g. an MD5 ETag) yields "".
+func NormalizeChecksum(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.Trim(value, `"`)
+	lower := strings.ToLower(value)
+	for _, prefix := range []string{"sha256:", "sha-256:", "sha256=", "sha-256="} {
+		if strings.HasPrefix(lower, prefix) {
+			value = value[len(prefix):]
+			break
+		}
+	}
+	value = strings.Trim(value, ":")
+
+	if raw, err := hex.DecodeString(value); err == nil && len(raw) == sha256.Size {
+		return strings.ToLower(value)
+	}
+	if raw, err := base64.StdEncoding.DecodeString(value); err == nil && len(raw) == sha256.Size {
+		return hex.EncodeToString(raw)
+	}
+	return ""
+}
+
+// headerChecksum returns the SHA-256 of the whole file announced in the response headers, if any
+// X-Checksum-SHA256, Repr-Digest and Digest describe the whole file; an ETag is
+// used when it is a strong SHA-256 digest. Content-Digest only covers the bytes
+// of this response, so it is used unless the response is a partial one.
+func headerChecksum(h http.Header, partial bool) string {
+	if sum := NormalizeChecksum(h.Get("X-Checksum-SHA256")); sum != "" {
+		return sum
+	}
+	names := []string{"Repr-Digest", "Digest"}
+	if !partial {
+		names = append(names, "Content-Digest")
+	}
+	for _, name := range names {
+		for _, entry := range strings.Split(h.Get(name), ",") {
+			if sum := NormalizeChecksum(entry); sum != "" {
+				return sum
+			}
+		}
+	}
+	if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return NormalizeChecksum(etag)
+	}
+	return ""
+}
+
+// FileSHA256 returns the size and hex-encoded SHA-256 of a local file
+func FileSHA256(path string) (*TransferDigest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	n, err := io.Copy(hasher, f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return &TransferDigest{Size: n, SHA256: hex.EncodeToString(hasher.Sum(nil))}, nil
+}
+
+// RemoteSHA256 streams the file with the given ID and returns the size and SHA-256 of the stored bytes
+// Nothing is written to disk. A short body or a mismatch with a checksum sent
+// by the server is reported as an error.
+func (c *Client) RemoteSHA256(ctx context.Context, id string) (*TransferDigest, error) {
+	return c.remoteDigest(ctx, id, false)
+}
+
+// ContentSHA256 is like RemoteSHA256, but a file that starts with an envelope
+// header is decrypted with c.Encryption and the digest describes its plaintext
+func (c *Client) ContentSHA256(ctx context.Context, id string) (*TransferDigest, error) {
+	if c.Encryption == nil {
+		return nil, fmt.Errorf("no decryption key is configured")
+	}
+	return c.remoteDigest(ctx, id, true)
+}
+
+// remoteDigest streams and hashes a stored file, decrypting it first if asked to and it is encrypted
+func (c *Client) remoteDigest(ctx context.Context, id string, decrypt bool) (*TransferDigest, error) {
+	ctx, cancel := withTimeout(ctx, c.TransferTimeout)
+	defer cancel()
+
+	fullURL, err := c.buildURL(fmt.Sprintf("/api/files/%s/download", id))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.requestDownload(ctx, fullURL, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, c.parseErrorResponse(resp, http.MethodGet, fullURL)
+	}
+
+	var reader io.Reader = resp.Body
+	if resp.ContentLength > 0 || c.Progress != nil {
+		progress, done := c.newProgress(resp.ContentLength, "Hashing")
+		defer done()
+		reader = io.TeeReader(resp.Body, progress)
+	}
+
+	// The server's checksum describes the stored bytes, so those are hashed separately
+	body := bufio.NewReader(reader)
+	head, _ := body.Peek(envelope.MagicSize)
+	stored, received := sha256.New(), &byteCounter{}
+	var content io.Reader = io.TeeReader(body, io.MultiWriter(stored, received))
+	decrypt = decrypt && envelope.IsEnvelope(head)
+	plain := sha256.New()
+	var sink io.Writer = io.Discard
+	if decrypt {
+		if content, err = envelope.NewDecryptReader(content, c.Encryption); err != nil {
+			return nil, fmt.Errorf("failed to decrypt file %s: %w", id, err)
+		}
+		sink = plain
+	}
+
+	n, err := io.Copy(sink, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", id, err)
+	}
+	if resp.ContentLength >= 0 && received.n != resp.ContentLength {
+		return nil, fmt.Errorf("connection closed after %d of %d bytes", received.n, resp.ContentLength)
+	}
+
+	storedDigest := &TransferDigest{Size: received.n, SHA256: hex.EncodeToString(stored.Sum(nil))}
+	if err := storedDigest.verify(id, headerChecksum(resp.Header, false)); err != nil {
+		return nil, err
+	}
+	if !decrypt {
+		return storedDigest, nil
+	}
+	return &TransferDigest{Size: n, SHA256: hex.EncodeToString(plain.Sum(nil)), Decrypted: true}, nil
+}
+
+// VerifyRemote checks that the stored file f has the content described by digest
+// The sizes are compared first. The checksum in f is used when there is one;
+// otherwise the file is downloaded and hashed without touching the disk.
+func (c *Client) VerifyRemote(ctx context.Context, f *file.FileResponse, digest *TransferDigest) error {
+	name := RemoteFilePath(f)
+	if f.FileSize != digest.Size {
+		return fmt.Errorf("size mismatch for %s: expected %d bytes, got %d", name, digest.Size, f.FileSize)
+	}
+	if err := digest.verify(name, f.Checksum); err != nil || digest.Verified {
+		return err
+	}
+
+	remote, err := c.RemoteSHA256(ctx, f.ID)
+	if err != nil {
+		return err
+	}
+	if remote.Size != digest.Size {
+		return fmt.Errorf("size mismatch for %s: expected %d bytes, got %d", name, digest.Size, remote.Size)
+	}
+	return digest.verify(name, remote.SHA256)
+}
@@ -0,0 +1,219 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+)
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestNormalizeChecksum(t *testing.T) {
+	sum := sha256.Sum256([]byte("hello"))
+	hexSum := hex.EncodeToString(sum[:])
+	b64Sum := base64.StdEncoding.EncodeToString(sum[:])
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{hexSum, hexSum},
+		{`"` + hexSum + `"`, hexSum},
+		{"sha256:" + hexSum, hexSum},
+		{"SHA-256=" + b64Sum, hexSum},
+		{"sha-256=:" + b64Sum + ":", hexSum},
+		{`"5d41402abc4b2a76b9719d911017c592"`, ""}, // MD5 ETag
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeChecksum(tt.input); got != tt.want {
+			t.Errorf("NormalizeChecksum(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestHeaderChecksum(t *testing.T) {
+	sum := sha256Hex("hello")
+	b64 := func(s string) string {
+		raw, _ := hex.DecodeString(s)
+		return base64.StdEncoding.EncodeToString(raw)
+	}
+
+	h := http.Header{}
+	h.Set("Content-Digest", "sha-256=:"+b64(sum)+":")
+	if got := headerChecksum(h, false); got != sum {
+		t.Errorf("Content-Digest: got %q, want %q", got, sum)
+	}
+	// Content-Digest only describes the bytes of a partial response
+	if got := headerChecksum(h, true); got != "" {
+		t.Errorf("Content-Digest on a partial response: got %q, want none", got)
+	}
+
+	h = http.Header{}
+	h.Set("Digest", "md5=XUFAKrxLKna5cZ2REBfFkg==, SHA-256="+b64(sum))
+	if got := headerChecksum(h, true); got != sum {
+		t.Errorf("Digest: got %q, want %q", got, sum)
+	}
+
+	h = http.Header{}
+	h.Set("ETag", `W/"`+sum+`"`)
+	if got := headerChecksum(h, false); got != "" {
+		t.Errorf("Weak ETag: got %q, want none", got)
+	}
+	h.Set("ETag", `"`+sum+`"`)
+	if got := headerChecksum(h, false); got != sum {
+		t.Errorf("ETag: got %q, want %q", got, sum)
+	}
+}
+
+func TestClient_DownloadFileDigest(t *testing.T) {
+	content := "verified content"
+	tests := []struct {
+		name         string
+		header       string
+		wantVerified bool
+		wantMismatch bool
+	}{
+		{name: "matching checksum", header: sha256Hex(content), wantVerified: true},
+		{name: "no checksum", header: ""},
+		{name: "corrupted download", header: sha256Hex("other content"), wantMismatch: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("X-Checksum-SHA256", tt.header)
+				}
+				io.WriteString(w, content)
+			})
+			defer server.Close()
+
+			c := NewClientWithConfig(server.URL, "")
+			c.Progress = io.Discard
+			outputPath := filepath.Join(t.TempDir(), "out.txt")
+
+			_, digest, err := c.DownloadFileDigest(context.Background(), "/api/files/id/download", outputPath, DownloadOptions{Resume: true})
+			if tt.wantMismatch {
+				var mismatch *ChecksumMismatchError
+				if !errors.As(err, &mismatch) {
+					t.Fatalf("Expected a ChecksumMismatchError, got %v", err)
+				}
+				// A corrupt file is discarded even when resuming is enabled
+				for _, p := range []string{outputPath, outputPath + partSuffix} {
+					if _, err := os.Stat(p); !os.IsNotExist(err) {
+						t.Errorf("Expected %s to be removed", p)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DownloadFileDigest() error = %v", err)
+			}
+			if digest.SHA256 != sha256Hex(content) || digest.Size != int64(len(content)) {
+				t.Errorf("Unexpected digest: %+v", digest)
+			}
+			if digest.Verified != tt.wantVerified {
+				t.Errorf("Verified = %v, want %v", digest.Verified, tt.wantVerified)
+			}
+		})
+	}
+}
+
+func TestClient_UploadFileDigest(t *testing.T) {
+	content := "uploaded content"
+	localPath := filepath.Join(t.TempDir(), "in.txt")
+	if err := os.WriteFile(localPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		response     file.FileResponse
+		wantVerified bool
+		wantErr      bool
+	}{
+		{name: "server echoes checksum", response: file.FileResponse{FileSize: int64(len(content)), Checksum: sha256Hex(content)}, wantVerified: true},
+		{name: "server reports no checksum", response: file.FileResponse{FileSize: int64(len(content))}},
+		{name: "server stored fewer bytes", response: file.FileResponse{FileSize: 3}, wantErr: true},
+		{name: "server checksum differs", response: file.FileResponse{Checksum: sha256Hex("x")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+				r.ParseMultipartForm(1 << 20)
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(tt.response)
+			})
+			defer server.Close()
+
+			c := NewClientWithConfig(server.URL, "")
+			c.Progress = io.Discard
+			digest, err := c.UploadFileDigest(context.Background(), "/api/files/upload", localPath, "", "", nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UploadFileDigest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if digest.SHA256 != sha256Hex(content) || digest.Verified != tt.wantVerified {
+				t.Errorf("Unexpected digest: %+v", digest)
+			}
+		})
+	}
+}
+
+func TestClient_VerifyRemote_ReadsBack(t *testing.T) {
+	content := "stored content"
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/files/file-1/download" {
+			t.Errorf("Unexpected request: %s", r.URL.Path)
+		}
+		io.WriteString(w, content)
+	})
+	defer server.Close()
+
+	c := NewClientWithConfig(server.URL, "")
+	c.Progress = io.Discard
+	f := &file.FileResponse{ID: "file-1", Filename: "a.txt", FileSize: int64(len(content))}
+
+	digest := &TransferDigest{Size: int64(len(content)), SHA256: sha256Hex(content)}
+	if err := c.VerifyRemote(context.Background(), f, digest); err != nil || !digest.Verified {
+		t.Errorf("VerifyRemote() = %v, verified %v; want a verified match", err, digest.Verified)
+	}
+
+	digest = &TransferDigest{Size: int64(len(content)), SHA256: sha256Hex("stored c0ntent")}
+	var mismatch *ChecksumMismatchError
+	if err := c.VerifyRemote(context.Background(), f, digest); !errors.As(err, &mismatch) {
+		t.Errorf("Expected a ChecksumMismatchError, got %v", err)
+	}
+}
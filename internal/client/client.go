@@ -16,25 +16,34 @@ limitations under the License.
 package client
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"io"
+	"log"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/schollz/progressbar/v3"
 	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/config"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/envelope"
 )
 
 const (
-	defaultTimeout = 30 * time.Second
+	// DefaultTimeout is the default deadline for a single API call
+	DefaultTimeout = 30 * time.Second
 )
 
 // Client represents an HTTP client for API communication
@@ -42,6 +51,30 @@ type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
 	APIKey     string
+
+	// Progress receives transferred bytes from UploadFile and DownloadFile
+	// When nil, each transfer renders its own progress bar
+	Progress io.Writer
+
+	// Retry controls how transient failures are retried
+	Retry RetryPolicy
+
+	// Logger receives diagnostic messages such as retries (nil disables logging)
+	Logger *log.Logger
+
+	// Timeout is the deadline for Get, Post, Put and Delete calls (0 means no limit)
+	Timeout time.Duration
+
+	// TransferTimeout is the deadline for UploadFile and DownloadFile (0 means no limit)
+	TransferTimeout time.Duration
+
+	// Encryption is the key for client-side encryption. Downloads of encrypted
+	// files are decrypted with it and fail when it is nil.
+	Encryption *envelope.Key
+
+	// EncryptUploads encrypts uploaded files with Encryption before they are sent
+	// and stores them under their name with envelope.Suffix appended
+	EncryptUploads bool
 }
 
 // NewClient creates a new API client instance
@@ -55,9 +88,11 @@ func NewClient() (*Client, error) {
 	client := &Client{
 		BaseURL: cfg.APIURL,
 		APIKey:  cfg.APIKey,
-		HTTPClient: &http.Client{
-			Timeout: defaultTimeout,
-		},
+		// Deadlines are applied per call through the request context so that
+		// long-running transfers are not cut off by a client-wide timeout
+		HTTPClient: &http.Client{},
+		Retry:      DefaultRetryPolicy(),
+		Timeout:    DefaultTimeout,
 	}
 
 	return client, nil
@@ -69,9 +104,11 @@ func NewClientWithConfig(baseURL, apiKey string) *Client {
 	return &Client{
 		BaseURL: baseURL,
 		APIKey:  apiKey,
-		HTTPClient: &http.Client{
-			Timeout: defaultTimeout,
-		},
+		// Deadlines are applied per call through the request context so that
+		// long-running transfers are not cut off by a client-wide timeout
+		HTTPClient: &http.Client{},
+		Retry:      DefaultRetryPolicy(),
+		Timeout:    DefaultTimeout,
 	}
 }
 
@@ -113,6 +150,54 @@ func (c *Client) setAuthHeaders(req *http.Request) {
 	}
 }
 
+// logf writes a diagnostic message if a logger is configured
+func (c *Client) logf(format string, args ...interface{}) {
+	if c.Logger != nil {
+		c.Logger.Printf(format, args...)
+	}
+}
+
+// newProgress returns the writer that transfer progress should be reported to
+// and a function to call once the transfer is done
+func (c *Client) newProgress(size int64, description string) (io.Writer, func()) {
+	if c.Progress != nil {
+		return c.Progress, func() {}
+	}
+	bar := progressbar.DefaultBytes(size, description)
+	return bar, func() { bar.Close() }
+}
+
+// UploadSize returns the number of bytes uploading a file of n bytes sends
+// Encrypted uploads send the whole envelope, which is larger than the file.
+func (c *Client) UploadSize(n int64) int64 {
+	if c.EncryptUploads {
+		return envelope.EncryptedSize(n)
+	}
+	return n
+}
+
+// attemptProgress forwards progress for one request and remembers how much was
+// reported, so a failed attempt's bytes can be taken back before a retry
+type attemptProgress struct {
+	w io.Writer
+	n int64
+}
+
+func (p *attemptProgress) Write(b []byte) (int, error) {
+	p.n += int64(len(b))
+	return p.w.Write(b)
+}
+
+// rewind takes back the bytes reported since the last rewind
+// This works for any progress bar that accepts negative increments, including
+// the aggregate bar shared by concurrent transfers, which must not be reset.
+func (p *attemptProgress) rewind() {
+	if bar, ok := p.w.(interface{ Add64(int64) error }); ok && p.n > 0 {
+		bar.Add64(-p.n)
+	}
+	p.n = 0
+}
+
 // parseErrorResponse parses an error response from the API
 func (c *Client) parseErrorResponse(resp *http.Response, method, url string) *APIError {
 	body, err := io.ReadAll(resp.Body)
@@ -144,36 +229,49 @@ func (c *Client) parseErrorResponse(resp *http.Response, method, url string) *AP
 	return &apiErr
 }
 
+// withTimeout derives a context bounded by timeout, or returns ctx unchanged if timeout is 0
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 // doRequest performs an HTTP request with the given method, path, and body
-func (c *Client) doRequest(method, path string, body interface{}) (*http.Response, error) {
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
 	fullURL, err := c.buildURL(path)
 	if err != nil {
 		return nil, err
 	}
 
-	var reqBody io.Reader
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		jsonData, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequest(method, fullURL, reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+	// Perform request, rebuilding it from the marshalled body on every attempt
+	resp, err := c.do(ctx, func(attempt int) (*http.Request, error) {
+		var reqBody io.Reader
+		if jsonData != nil {
+			reqBody = bytes.NewReader(jsonData)
+		}
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+		req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
 
-	// Add authentication headers
-	c.setAuthHeaders(req)
+		// Set headers
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
 
-	// Perform request
-	resp, err := c.HTTPClient.Do(req)
+		// Add authentication headers
+		c.setAuthHeaders(req)
+		return req, nil
+	}, true)
 	if err != nil {
 		return nil, fmt.Errorf("request failed [%s %s]: %w", method, fullURL, err)
 	}
@@ -187,9 +285,12 @@ func (c *Client) doRequest(method, path string, body interface{}) (*http.Respons
 	return resp, nil
 }
 
-// Get performs a GET request and unmarshals the response into result
-func (c *Client) Get(path string, result interface{}) error {
-	resp, err := c.doRequest(http.MethodGet, path, nil)
+// requestJSON performs a request bounded by c.Timeout and unmarshals the response into result
+func (c *Client) requestJSON(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	ctx, cancel := withTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	resp, err := c.doRequest(ctx, method, path, body)
 	if err != nil {
 		return err
 	}
@@ -199,75 +300,56 @@ func (c *Client) Get(path string, result interface{}) error {
 		return nil
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	if err := json.Unmarshal(body, result); err != nil {
+	if err := json.Unmarshal(respBody, result); err != nil {
 		return fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return nil
 }
 
-// Post performs a POST request with a JSON body and unmarshals the response into result
-func (c *Client) Post(path string, body interface{}, result interface{}) error {
-	resp, err := c.doRequest(http.MethodPost, path, body)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if result == nil {
-		return nil
-	}
+// Get performs a GET request and unmarshals the response into result
+func (c *Client) Get(path string, result interface{}) error {
+	return c.GetContext(context.Background(), path, result)
+}
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
+// GetContext performs a GET request bound to ctx and unmarshals the response into result
+func (c *Client) GetContext(ctx context.Context, path string, result interface{}) error {
+	return c.requestJSON(ctx, http.MethodGet, path, nil, result)
+}
 
-	if err := json.Unmarshal(respBody, result); err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
-	}
+// Post performs a POST request with a JSON body and unmarshals the response into result
+func (c *Client) Post(path string, body interface{}, result interface{}) error {
+	return c.PostContext(context.Background(), path, body, result)
+}
 
-	return nil
+// PostContext performs a POST request bound to ctx with a JSON body and unmarshals the response into result
+func (c *Client) PostContext(ctx context.Context, path string, body interface{}, result interface{}) error {
+	return c.requestJSON(ctx, http.MethodPost, path, body, result)
 }
 
 // Put performs a PUT request with a JSON body and unmarshals the response into result
 func (c *Client) Put(path string, body interface{}, result interface{}) error {
-	resp, err := c.doRequest(http.MethodPut, path, body)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if result == nil {
-		return nil
-	}
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if err := json.Unmarshal(respBody, result); err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
-	}
+	return c.PutContext(context.Background(), path, body, result)
+}
 
-	return nil
+// PutContext performs a PUT request bound to ctx with a JSON body and unmarshals the response into result
+func (c *Client) PutContext(ctx context.Context, path string, body interface{}, result interface{}) error {
+	return c.requestJSON(ctx, http.MethodPut, path, body, result)
 }
 
 // Delete performs a DELETE request
 func (c *Client) Delete(path string) error {
-	resp, err := c.doRequest(http.MethodDelete, path, nil)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
+	return c.DeleteContext(context.Background(), path)
+}
 
-	return nil
+// DeleteContext performs a DELETE request bound to ctx
+func (c *Client) DeleteContext(ctx context.Context, path string) error {
+	return c.requestJSON(ctx, http.MethodDelete, path, nil, nil)
 }
 
 // UpdateAuth updates the authentication credentials in the client
@@ -281,110 +363,258 @@ func (c *Client) UpdateAuth(apiKey string) {
 // folderPath: Optional folder path (can be empty string)
 // result: Pointer to struct to unmarshal JSON response into
 func (c *Client) UploadFile(path string, filePath string, folderPath string, filename string, result interface{}) error {
+	return c.UploadFileContext(context.Background(), path, filePath, folderPath, filename, result)
+}
+
+// UploadFileContext performs a multipart/form-data file upload bound to ctx
+// The upload is additionally bounded by c.TransferTimeout when it is set.
+func (c *Client) UploadFileContext(ctx context.Context, path string, filePath string, folderPath string, filename string, result interface{}) error {
+	_, err := c.UploadFileDigest(ctx, path, filePath, folderPath, filename, result)
+	return err
+}
+
+// UploadFileDigest uploads a file like UploadFileContext and also returns the SHA-256 of the data sent
+// The digest is compared against the size and checksum the server reports
+// back, when it reports them.
+func (c *Client) UploadFileDigest(ctx context.Context, path string, filePath string, folderPath string, filename string, result interface{}) (*TransferDigest, error) {
 	// Open the file
 	file, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
+		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close()
 
-	// Get file size for progress bar
+	// Get file size for progress bar and Content-Length
 	fileInfo, err := file.Stat()
 	if err != nil {
-		return fmt.Errorf("failed to get file info: %w", err)
+		return nil, fmt.Errorf("failed to get file info: %w", err)
 	}
-	fileSize := fileInfo.Size()
 
-	// Create multipart form data
-	var body bytes.Buffer
-	writer := multipart.NewWriter(&body)
+	ctx, cancel := withTimeout(ctx, c.TransferTimeout)
+	defer cancel()
 
-	// Add file field
-	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
-	if err != nil {
-		return fmt.Errorf("failed to create form file field: %w", err)
+	var content io.Reader = file
+	size, name := fileInfo.Size(), filepath.Base(filePath)
+	if c.EncryptUploads {
+		if c.Encryption == nil {
+			return nil, fmt.Errorf("encryption requested but no key is configured")
+		}
+		if content, err = envelope.NewEncryptReader(file, c.Encryption); err != nil {
+			return nil, err
+		}
+		size, name = c.UploadSize(size), name+envelope.Suffix
+		if filename != "" {
+			filename += envelope.Suffix
+		}
 	}
 
-	// Create progress bar for upload
-	bar := progressbar.DefaultBytes(fileSize, "Uploading")
+	return c.uploadStream(ctx, path, content, size, name, folderPath, filename, result)
+}
 
-	// Copy file content to form field with progress tracking
-	_, err = io.Copy(io.MultiWriter(part, bar), file)
+// uploadStream streams content as a multipart/form-data upload without buffering it in memory
+// The multipart body is produced through an io.Pipe while the request is being sent, so the
+// progress bar advances as bytes are handed to the connection.
+// size: number of bytes in content, or -1 if unknown (the request is then sent chunked)
+// name: filename reported in the multipart file part
+// The returned digest covers the bytes sent by the successful attempt.
+func (c *Client) uploadStream(ctx context.Context, path string, content io.Reader, size int64, name string, folderPath string, filename string, result interface{}) (*TransferDigest, error) {
+	// Build URL
+	fullURL, err := c.buildURL(path)
 	if err != nil {
-		bar.Close()
-		return fmt.Errorf("failed to copy file content: %w", err)
+		return nil, err
 	}
-	bar.Close()
 
-	// Add optional folderPath field
-	if folderPath != "" {
-		err = writer.WriteField("folderPath", folderPath)
-		if err != nil {
-			return fmt.Errorf("failed to write folderPath field: %w", err)
+	fields := uploadFields(folderPath, filename)
+
+	// Only seekable content can be rewound and sent again on retry
+	seeker, replayable := content.(io.Seeker)
+
+	// Create progress bar for upload
+	bar, done := c.newProgress(size, "Uploading")
+	defer done()
+	progress := &attemptProgress{w: bar}
+
+	// stopWriter closes the current pipe and waits for its body writer to exit,
+	// so content is never read by two attempts at once
+	var pr *io.PipeReader
+	var writerDone chan struct{}
+	var hasher hash.Hash
+	var sent *byteCounter
+	stopWriter := func() {
+		if pr != nil {
+			pr.Close()
+			<-writerDone
 		}
 	}
+	defer stopWriter()
 
-	// Add optional filename field
-	if filename != "" {
-		err = writer.WriteField("filename", filename)
+	resp, err := c.do(ctx, func(attempt int) (*http.Request, error) {
+		if attempt > 0 {
+			stopWriter()
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return nil, fmt.Errorf("failed to rewind file for retry: %w", err)
+			}
+			progress.rewind()
+		}
+
+		var pw *io.PipeWriter
+		pr, pw = io.Pipe()
+		writerDone = make(chan struct{})
+		writer := multipart.NewWriter(pw)
+
+		// Compute the exact body size up front so the server gets a Content-Length
+		contentLength := int64(-1)
+		if size >= 0 {
+			overhead, err := multipartOverhead(writer.Boundary(), name, fields)
+			if err != nil {
+				return nil, err
+			}
+			contentLength = overhead + size
+		}
+
+		// Create request
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, pr)
 		if err != nil {
-			return fmt.Errorf("failed to write filename field: %w", err)
+			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
-	}
 
-	// Close the multipart writer to finalize the form
-	err = writer.Close()
+		// Set Content-Type header with boundary
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Accept", "application/json")
+		req.ContentLength = contentLength
+
+		// Add authentication headers
+		c.setAuthHeaders(req)
+
+		// Produce the multipart body while the transport consumes it, hashing what is sent
+		finished := writerDone
+		hasher, sent = sha256.New(), &byteCounter{}
+		tee := io.TeeReader(content, io.MultiWriter(progress, hasher, sent))
+		go func() {
+			defer close(finished)
+			pw.CloseWithError(writeMultipartBody(writer, name, tee, fields))
+		}()
+
+		return req, nil
+	}, replayable)
 	if err != nil {
-		return fmt.Errorf("failed to close multipart writer: %w", err)
+		return nil, fmt.Errorf("request failed [POST %s]: %w", fullURL, err)
 	}
+	defer resp.Body.Close()
 
-	// Build URL
-	fullURL, err := c.buildURL(path)
-	if err != nil {
-		return err
+	// Check for error status codes
+	if resp.StatusCode >= 400 {
+		return nil, c.parseErrorResponse(resp, http.MethodPost, fullURL)
 	}
 
-	// Create request
-	req, err := http.NewRequest(http.MethodPost, fullURL, &body)
+	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// Set Content-Type header with boundary
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("Accept", "application/json")
-	req.ContentLength = int64(body.Len())
+	// Parse response if result is provided
+	if result != nil {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
 
-	// Add authentication headers
-	c.setAuthHeaders(req)
+	// The body writer has finished once the server answered; wait for it before reading the hash
+	stopWriter()
+	digest := &TransferDigest{Size: sent.n, SHA256: hex.EncodeToString(hasher.Sum(nil))}
+	if size >= 0 && sent.n != size {
+		return nil, fmt.Errorf("upload ended after %d of %d bytes", sent.n, size)
+	}
 
-	// Perform request
-	resp, err := c.HTTPClient.Do(req)
+	// Compare against what the server says it stored
+	var stored struct {
+		FileSize int64  `json:"fileSize"`
+		Checksum string `json:"checksum"`
+	}
+	json.Unmarshal(respBody, &stored)
+	if stored.FileSize > 0 && stored.FileSize != digest.Size {
+		return nil, fmt.Errorf("server stored %d of %d bytes", stored.FileSize, digest.Size)
+	}
+	expected := stored.Checksum
+	if expected == "" {
+		expected = headerChecksum(resp.Header, false)
+	}
+	if err := digest.verify(name, expected); err != nil {
+		return nil, err
+	}
+	return digest, nil
+}
+
+// formField is a single non-file multipart form field
+type formField struct {
+	name  string
+	value string
+}
+
+// uploadFields returns the optional form fields sent alongside an uploaded file
+func uploadFields(folderPath, filename string) []formField {
+	var fields []formField
+	if folderPath != "" {
+		fields = append(fields, formField{name: "folderPath", value: folderPath})
+	}
+	if filename != "" {
+		fields = append(fields, formField{name: "filename", value: filename})
+	}
+	return fields
+}
+
+// writeMultipartBody writes the file part followed by the form fields and closes the writer
+func writeMultipartBody(writer *multipart.Writer, name string, content io.Reader, fields []formField) error {
+	// Add file field
+	part, err := writer.CreateFormFile("file", name)
 	if err != nil {
-		return fmt.Errorf("request failed [POST %s]: %w", fullURL, err)
+		return fmt.Errorf("failed to create form file field: %w", err)
 	}
-	defer resp.Body.Close()
 
-	// Check for error status codes
-	if resp.StatusCode >= 400 {
-		return c.parseErrorResponse(resp, http.MethodPost, fullURL)
+	// Copy file content to form field
+	if _, err := io.Copy(part, content); err != nil {
+		return fmt.Errorf("failed to copy file content: %w", err)
 	}
 
-	// Parse response if result is provided
-	if result != nil {
-		respBody, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("failed to read response body: %w", err)
+	// Add optional fields
+	for _, field := range fields {
+		if err := writer.WriteField(field.name, field.value); err != nil {
+			return fmt.Errorf("failed to write %s field: %w", field.name, err)
 		}
+	}
 
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("failed to unmarshal response: %w", err)
-		}
+	// Close the multipart writer to finalize the form
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart writer: %w", err)
 	}
 
 	return nil
 }
 
+// multipartOverhead returns the number of bytes a multipart body adds around the file content
+// It renders the same form with an empty file part using the same boundary.
+func multipartOverhead(boundary, name string, fields []formField) (int64, error) {
+	var counter byteCounter
+	writer := multipart.NewWriter(&counter)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return 0, fmt.Errorf("failed to set multipart boundary: %w", err)
+	}
+	if err := writeMultipartBody(writer, name, strings.NewReader(""), fields); err != nil {
+		return 0, err
+	}
+	return counter.n, nil
+}
+
+// byteCounter is an io.Writer that only counts the bytes written to it
+type byteCounter struct {
+	n int64
+}
+
+func (b *byteCounter) Write(p []byte) (int, error) {
+	b.n += int64(len(p))
+	return len(p), nil
+}
+
 // extractFilenameFromContentDisposition extracts filename from Content-Disposition header
 // Handles formats like: attachment; filename="filename.ext" or attachment; filename=filename.ext
 func extractFilenameFromContentDisposition(header string) string {
@@ -427,110 +657,393 @@ func sanitizeFilename(filename string) string {
 	return filename
 }
 
+// DownloadOptions controls how a download is written to disk
+type DownloadOptions struct {
+	// Resume continues an interrupted download from its .part file using an HTTP Range request
+	// and keeps the .part file if the transfer fails again
+	Resume bool
+	// Checksum is the expected SHA-256 of the complete file; when empty, a checksum
+	// announced in the response headers is used if there is one
+	Checksum string
+}
+
+// partSuffix is appended to the output path while a download is in progress
+const partSuffix = ".part"
+
 // DownloadFile downloads a file from the API and saves it to the specified output path
 // path: API endpoint path (e.g., "/api/files/{id}/download")
 // outputPath: Local file path to save the downloaded file (can be directory or full path)
 // Returns the final file path where the file was saved
 func (c *Client) DownloadFile(path string, outputPath string) (string, error) {
+	return c.DownloadFileWithOptions(path, outputPath, DownloadOptions{})
+}
+
+// DownloadFileWithOptions downloads a file like DownloadFile, honouring opts
+// Data is written to "<final path>.part" and only renamed into place once the
+// transfer has completed.
+func (c *Client) DownloadFileWithOptions(path string, outputPath string, opts DownloadOptions) (string, error) {
+	return c.DownloadFileContext(context.Background(), path, outputPath, opts)
+}
+
+// DownloadFileContext downloads a file bound to ctx, honouring opts
+// The download is additionally bounded by c.TransferTimeout when it is set.
+// If ctx is cancelled mid-transfer the .part file is removed unless opts.Resume is set.
+func (c *Client) DownloadFileContext(ctx context.Context, path string, outputPath string, opts DownloadOptions) (string, error) {
+	finalPath, _, err := c.DownloadFileDigest(ctx, path, outputPath, opts)
+	return finalPath, err
+}
+
+// DownloadFileDigest downloads a file like DownloadFileContext and also returns its SHA-256
+// The digest is computed while streaming. A download that does not match the
+// expected checksum is discarded, even with opts.Resume, and returns a
+// *ChecksumMismatchError.
+func (c *Client) DownloadFileDigest(ctx context.Context, path string, outputPath string, opts DownloadOptions) (string, *TransferDigest, error) {
+	ctx, cancel := withTimeout(ctx, c.TransferTimeout)
+	defer cancel()
+
 	// Build URL
 	fullURL, err := c.buildURL(path)
 	if err != nil {
-		return "", err
+		return "", nil, err
+	}
+
+	// With an explicit output path the partial file is known up front, so a
+	// resumed download asks for the missing range straight away
+	var offset int64
+	knownPath, known := explicitOutputPath(outputPath)
+	if opts.Resume && known {
+		offset = resumeOffset(knownPath + partSuffix)
 	}
 
-	// Create request
-	req, err := http.NewRequest(http.MethodGet, fullURL, nil)
+	// Perform request
+	resp, err := c.requestDownload(ctx, fullURL, offset)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", nil, err
+	}
+	defer func() {
+		if resp != nil {
+			resp.Body.Close()
+		}
+	}()
+	if resp, offset, err = c.checkRange(ctx, fullURL, resp, offset); err != nil {
+		return "", nil, err
 	}
 
-	// Set headers
-	req.Header.Set("Accept", "*/*")
+	// Check for error status codes before anything is created on disk
+	if resp.StatusCode >= 400 {
+		return "", nil, c.parseErrorResponse(resp, http.MethodGet, fullURL)
+	}
 
-	// Add authentication headers
-	c.setAuthHeaders(req)
+	// Encrypted files are recognised by their header and decrypted on the way to disk
+	encrypted := false
+	if offset == 0 {
+		body := bufio.NewReader(resp.Body)
+		head, _ := body.Peek(envelope.MagicSize)
+		encrypted = envelope.IsEnvelope(head)
+		resp.Body = bufferedBody{Reader: body, Closer: resp.Body}
+	}
+	filename := downloadFilename(resp, path)
+	if encrypted {
+		if c.Encryption == nil {
+			return "", nil, fmt.Errorf("file is encrypted and no decryption key is configured")
+		}
+		if err := c.Encryption.Unlock(false); err != nil {
+			return "", nil, err
+		}
+		if envelope.IsEncryptedName(filename) {
+			filename = strings.TrimSuffix(filename, envelope.Suffix)
+		}
+	}
 
-	// Perform request
-	resp, err := c.HTTPClient.Do(req)
+	// Determine final output path
+	finalPath, err := resolveOutputPath(outputPath, filename)
 	if err != nil {
-		return "", fmt.Errorf("request failed [GET %s]: %w", fullURL, err)
+		return "", nil, err
+	}
+	partPath := finalPath + partSuffix
+
+	// Otherwise the partial file is only known now, so the range is asked for separately
+	if opts.Resume && !known && !encrypted {
+		if offset = resumeOffset(partPath); offset > 0 {
+			resp.Body.Close()
+			if resp, err = c.requestDownload(ctx, fullURL, offset); err != nil {
+				return "", nil, err
+			}
+			if resp, offset, err = c.checkRange(ctx, fullURL, resp, offset); err != nil {
+				return "", nil, err
+			}
+			if resp.StatusCode >= 400 {
+				return "", nil, c.parseErrorResponse(resp, http.MethodGet, fullURL)
+			}
+		}
 	}
-	defer resp.Body.Close()
 
-	// Check for error status codes
-	if resp.StatusCode >= 400 {
-		return "", c.parseErrorResponse(resp, http.MethodGet, fullURL)
+	// Open the partial file, appending only when the server honoured the range.
+	// Encrypted downloads hold plaintext, so they use a temporary file of their
+	// own that a later resume can never mistake for a partial download.
+	var outFile *os.File
+	if encrypted {
+		// An earlier partial download of the same file can never be resumed
+		os.Remove(partPath)
+		outFile, err = os.CreateTemp(filepath.Dir(finalPath), "."+filepath.Base(finalPath)+".*"+partSuffix)
+		if err == nil {
+			partPath = outFile.Name()
+		}
+	} else {
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if offset > 0 {
+			flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		}
+		outFile, err = os.OpenFile(partPath, flags, 0644)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create output file: %w", err)
 	}
 
-	// Get content length for progress bar
+	// Hash the bytes already on disk so the digest covers the whole file
+	hasher := sha256.New()
+	if offset > 0 {
+		if err := hashFile(hasher, partPath); err != nil {
+			outFile.Close()
+			return "", nil, err
+		}
+	}
+
+	// Create progress bar for download (only if content length is known)
 	contentLength := resp.ContentLength
+	description := "Downloading"
+	if offset > 0 {
+		description = "Resuming"
+	}
+	var reader io.Reader = resp.Body
+	done := func() {}
+	if contentLength > 0 || c.Progress != nil {
+		var progress io.Writer
+		progress, done = c.newProgress(contentLength, description)
+		reader = io.TeeReader(resp.Body, progress)
+	}
 
-	// Extract filename from Content-Disposition header
-	contentDisposition := resp.Header.Get("Content-Disposition")
-	filename := extractFilenameFromContentDisposition(contentDisposition)
+	// Stream response body to file with progress tracking, hashing the bytes as received.
+	// The plaintext of an encrypted file is hashed too, as that is what ends up on disk.
+	received := &byteCounter{}
+	var content io.Reader = io.TeeReader(reader, io.MultiWriter(hasher, received))
+	plain := sha256.New()
+	var plainSize int64
+	if encrypted {
+		content, err = envelope.NewDecryptReader(content, c.Encryption)
+	}
+	if err == nil {
+		plainSize, err = io.Copy(io.MultiWriter(outFile, plain), content)
+	}
+	if encrypted && err != nil && ctx.Err() == nil {
+		err = fmt.Errorf("failed to decrypt file: %w", err)
+	}
+	done()
+	written := received.n
+	if err == nil && contentLength >= 0 && written != contentLength {
+		err = fmt.Errorf("connection closed after %d of %d bytes", written, contentLength)
+	}
+	if closeErr := outFile.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Keep the partial file only if the download can be resumed later
+		if !opts.Resume || encrypted {
+			os.Remove(partPath)
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", nil, fmt.Errorf("download interrupted: %w", ctxErr)
+		}
+		if encrypted {
+			return "", nil, err
+		}
+		return "", nil, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	// A corrupt file cannot be resumed, so it is never kept
+	digest := &TransferDigest{Size: offset + written, SHA256: hex.EncodeToString(hasher.Sum(nil))}
+	expected := opts.Checksum
+	if expected == "" {
+		expected = headerChecksum(resp.Header, offset > 0)
+	}
+	if err := digest.verify(finalPath, expected); err != nil {
+		os.Remove(partPath)
+		return "", nil, err
+	}
+	if encrypted {
+		digest = &TransferDigest{
+			Size:      plainSize,
+			SHA256:    hex.EncodeToString(plain.Sum(nil)),
+			Verified:  digest.Verified,
+			Decrypted: true,
+		}
+	}
+
+	// Move the completed download into place
+	if err := os.Rename(partPath, finalPath); err != nil {
+		return "", nil, fmt.Errorf("failed to move download into place: %w", err)
+	}
+
+	return finalPath, digest, nil
+}
+
+// bufferedBody is a response body read through a bufio.Reader
+type bufferedBody struct {
+	*bufio.Reader
+	io.Closer
+}
+
+// hashFile feeds the contents of the local file at path into w
+func hashFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read partial file: %w", err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("failed to read partial file: %w", err)
+	}
+	return nil
+}
+
+// requestDownload sends the GET request for a download, starting at offset when it is positive
+func (c *Client) requestDownload(ctx context.Context, fullURL string, offset int64) (*http.Response, error) {
+	resp, err := c.do(ctx, func(attempt int) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		// Set headers
+		req.Header.Set("Accept", "*/*")
+		if offset > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		}
+
+		// Add authentication headers
+		c.setAuthHeaders(req)
+		return req, nil
+	}, true)
+	if err != nil {
+		return nil, fmt.Errorf("request failed [GET %s]: %w", fullURL, err)
+	}
+	return resp, nil
+}
+
+// checkRange makes sure resp continues a download at offset
+// A server that ignores or rejects the range sends the whole file instead, in
+// which case the returned offset is 0.
+func (c *Client) checkRange(ctx context.Context, fullURL string, resp *http.Response, offset int64) (*http.Response, int64, error) {
+	if offset == 0 {
+		return resp, 0, nil
+	}
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if start, ok := contentRangeStart(resp.Header.Get("Content-Range")); ok && start == offset {
+			return resp, offset, nil
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+	default:
+		return resp, 0, nil
+	}
+	resp.Body.Close()
+	resp, err := c.requestDownload(ctx, fullURL, 0)
+	return resp, 0, err
+}
+
+// resumeOffset returns the size of a partial download to resume, or 0 to start over
+// Encrypted files are decrypted on the way to disk, so they are never resumed:
+// a partial file that starts with an envelope header, or is too short to tell,
+// is downloaded again from the start.
+func resumeOffset(partPath string) int64 {
+	f, err := os.Open(partPath)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
 
-	// Sanitize filename
+	info, err := f.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		return 0
+	}
+	head := make([]byte, envelope.MagicSize)
+	if _, err := io.ReadFull(f, head); err != nil || envelope.IsEnvelope(head) {
+		return 0
+	}
+	return info.Size()
+}
+
+// explicitOutputPath returns outputPath if it names a file rather than a directory,
+// so the destination is known before the response arrives
+func explicitOutputPath(outputPath string) (string, bool) {
+	if outputPath == "" {
+		return "", false
+	}
+	if info, err := os.Stat(outputPath); err == nil && info.IsDir() {
+		return "", false
+	}
+	return outputPath, true
+}
+
+// downloadFilename returns the sanitized filename for a download response
+// It prefers the Content-Disposition header and falls back to the last path segment.
+func downloadFilename(resp *http.Response, path string) string {
+	// Extract filename from Content-Disposition header
+	filename := extractFilenameFromContentDisposition(resp.Header.Get("Content-Disposition"))
 	if filename != "" {
-		filename = sanitizeFilename(filename)
-	} else {
-		// If no filename in header, extract from path or use default
-		filename = "download"
-		// Try to extract file ID from path as fallback
-		parts := strings.Split(path, "/")
-		if len(parts) > 0 {
-			lastPart := parts[len(parts)-1]
-			if lastPart != "download" && lastPart != "" {
-				filename = lastPart
-			}
+		return sanitizeFilename(filename)
+	}
+
+	// If no filename in header, extract from path or use default
+	filename = "download"
+	// Try to extract file ID from path as fallback
+	parts := strings.Split(path, "/")
+	if len(parts) > 0 {
+		lastPart := parts[len(parts)-1]
+		if lastPart != "download" && lastPart != "" {
+			filename = lastPart
 		}
 	}
+	return filename
+}
 
-	// Determine final output path
-	var finalPath string
+// resolveOutputPath determines where a download named filename is saved
+// outputPath may be a directory, a file path, or empty for the current directory.
+func resolveOutputPath(outputPath, filename string) (string, error) {
 	outputPathInfo, err := os.Stat(outputPath)
 	if err == nil && outputPathInfo.IsDir() {
 		// Output path is a directory, combine with filename
-		finalPath = filepath.Join(outputPath, filename)
-	} else if outputPath != "" {
-		// Output path is specified and not a directory, use it as-is
-		finalPath = outputPath
-		// Create parent directory if it doesn't exist
-		parentDir := filepath.Dir(finalPath)
-		if parentDir != "." && parentDir != "" {
-			if err := os.MkdirAll(parentDir, 0755); err != nil {
-				return "", fmt.Errorf("failed to create output directory: %w", err)
-			}
-		}
-	} else {
+		return filepath.Join(outputPath, filename), nil
+	}
+	if outputPath == "" {
 		// No output path specified, use current directory with filename
-		finalPath = filename
+		return filename, nil
 	}
 
-	// Create output file
-	outFile, err := os.Create(finalPath)
-	if err != nil {
-		return "", fmt.Errorf("failed to create output file: %w", err)
+	// Output path is specified and not a directory, use it as-is
+	// Create parent directory if it doesn't exist
+	parentDir := filepath.Dir(outputPath)
+	if parentDir != "." && parentDir != "" {
+		if err := os.MkdirAll(parentDir, 0755); err != nil {
+			return "", fmt.Errorf("failed to create output directory: %w", err)
+		}
 	}
-	defer outFile.Close()
+	return outputPath, nil
+}
 
-	// Create progress bar for download (only if content length is known)
-	var reader io.Reader = resp.Body
-	var bar *progressbar.ProgressBar
-	if contentLength > 0 {
-		bar = progressbar.DefaultBytes(contentLength, "Downloading")
-		reader = io.TeeReader(resp.Body, bar)
+// contentRangeStart parses the first byte position from a "bytes start-end/total" header
+func contentRangeStart(header string) (int64, bool) {
+	spec, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, false
 	}
-
-	// Stream response body to file with progress tracking
-	_, err = io.Copy(outFile, reader)
-	if bar != nil {
-		bar.Close()
+	startStr, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, false
 	}
+	start, err := strconv.ParseInt(strings.TrimSpace(startStr), 10, 64)
 	if err != nil {
-		// Clean up file on error
-		os.Remove(finalPath)
-		return "", fmt.Errorf("failed to write file: %w", err)
+		return 0, false
 	}
-
-	return finalPath, nil
+	return start, true
 }
@@ -16,12 +16,23 @@ limitations under the License.
 package client
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/envelope"
 )
 
 // setupTestServer creates a mock HTTP server for testing
@@ -351,6 +362,59 @@ func TestClient_UploadFile(t *testing.T) {
 	}
 }
 
+func TestClient_UploadFile_StreamsWithContentLength(t *testing.T) {
+	content := strings.Repeat("0123456789", 100000) // ~1MB
+
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Failed to read request body: %v", err)
+			return
+		}
+
+		// Content-Length must be computed up front and match what was sent
+		if r.ContentLength <= int64(len(content)) {
+			t.Errorf("Expected Content-Length larger than file size, got %d", r.ContentLength)
+		}
+		if r.ContentLength != int64(len(body)) {
+			t.Errorf("Content-Length %d does not match body size %d", r.ContentLength, len(body))
+		}
+
+		// Body must still be a valid multipart form
+		reader := multipart.NewReader(bytes.NewReader(body), strings.TrimPrefix(
+			r.Header.Get("Content-Type"), "multipart/form-data; boundary="))
+		form, err := reader.ReadForm(10 << 20)
+		if err != nil {
+			t.Errorf("Failed to parse multipart form: %v", err)
+			return
+		}
+		if got := form.Value["folderPath"]; len(got) != 1 || got[0] != "/videos" {
+			t.Errorf("Expected folderPath /videos, got %v", got)
+		}
+		if files := form.File["file"]; len(files) != 1 || files[0].Size != int64(len(content)) {
+			t.Errorf("Expected uploaded file of %d bytes", len(content))
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "123"})
+	})
+	defer server.Close()
+
+	tmpFile := t.TempDir() + "/large.bin"
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+
+	client := NewClientWithConfig(server.URL, "")
+	var result map[string]interface{}
+	if err := client.UploadFile("/api/files/upload", tmpFile, "/videos", "", &result); err != nil {
+		t.Fatalf("Client.UploadFile() error = %v", err)
+	}
+	if result["id"] != "123" {
+		t.Errorf("Expected id 123, got %v", result["id"])
+	}
+}
+
 func TestClient_DownloadFile(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -438,6 +502,308 @@ func TestClient_DownloadFile(t *testing.T) {
 	}
 }
 
+func TestClient_DownloadFile_Resume(t *testing.T) {
+	content := "0123456789abcdefghij"
+	tests := []struct {
+		name        string
+		partial     string
+		honourRange bool
+	}{
+		{name: "server honours range", partial: content[:8], honourRange: true},
+		{name: "server ignores range", partial: "garbage!", honourRange: false},
+		{name: "no partial file", honourRange: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRange string
+			var requests int
+			server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				gotRange = r.Header.Get("Range")
+				w.Header().Set("Content-Disposition", `attachment; filename="test.txt"`)
+				var start int
+				if tt.honourRange && gotRange != "" {
+					fmt.Sscanf(gotRange, "bytes=%d-", &start)
+					w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+					w.WriteHeader(http.StatusPartialContent)
+				}
+				w.Write([]byte(content[start:]))
+			})
+			defer server.Close()
+
+			outputPath := t.TempDir() + "/test.txt"
+			if tt.partial != "" {
+				if err := os.WriteFile(outputPath+partSuffix, []byte(tt.partial), 0644); err != nil {
+					t.Fatalf("Failed to create partial file: %v", err)
+				}
+			}
+
+			client := NewClientWithConfig(server.URL, "")
+			client.Progress = io.Discard
+			filePath, err := client.DownloadFileWithOptions("/api/files/123/download", outputPath, DownloadOptions{Resume: true})
+			if err != nil {
+				t.Fatalf("DownloadFileWithOptions() error = %v", err)
+			}
+
+			if tt.partial != "" && gotRange != fmt.Sprintf("bytes=%d-", len(tt.partial)) {
+				t.Errorf("Expected Range header for %d bytes, got %q", len(tt.partial), gotRange)
+			}
+			// The partial file is known up front, so no full transfer is started first
+			if requests != 1 {
+				t.Errorf("Expected 1 request, got %d", requests)
+			}
+			data, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to read downloaded file: %v", err)
+			}
+			if string(data) != content {
+				t.Errorf("Expected file content %q, got %q", content, data)
+			}
+			if _, err := os.Stat(outputPath + partSuffix); !os.IsNotExist(err) {
+				t.Error("Expected .part file to be renamed into place")
+			}
+		})
+	}
+}
+
+func TestClient_DownloadFile_NotFoundCreatesNothing(t *testing.T) {
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{"message": "File not found"})
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := NewClientWithConfig(server.URL, "")
+	if _, err := client.DownloadFile("/api/files/123/download", dir+"/missing/dir/test.txt"); err == nil {
+		t.Fatal("Expected error for missing file")
+	}
+	if _, err := os.Stat(dir + "/missing"); !os.IsNotExist(err) {
+		t.Error("Expected no output directory to be created for a failed download")
+	}
+}
+
+func TestClient_DownloadFile_KeepsPartialOnResume(t *testing.T) {
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		// Promise more bytes than are sent to simulate a dropped connection
+		w.Header().Set("Content-Length", "100")
+		w.Write([]byte("only part"))
+	})
+	defer server.Close()
+
+	outputPath := t.TempDir() + "/test.txt"
+	client := NewClientWithConfig(server.URL, "")
+	client.Progress = io.Discard
+	client.Retry.MaxAttempts = 1
+
+	if _, err := client.DownloadFileWithOptions("/api/files/123/download", outputPath, DownloadOptions{Resume: true}); err == nil {
+		t.Fatal("Expected error for truncated download")
+	}
+	if _, err := os.Stat(outputPath + partSuffix); err != nil {
+		t.Errorf("Expected .part file to be kept for resume: %v", err)
+	}
+	if _, err := os.Stat(outputPath); !os.IsNotExist(err) {
+		t.Error("Expected no file at the final path")
+	}
+}
+
+func TestClient_EncryptedRoundTrip(t *testing.T) {
+	content := strings.Repeat("confidential ", 20000) // several chunks
+	var stored []byte
+	var storedName string
+
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			r.ParseMultipartForm(10 << 20)
+			part, header, err := r.FormFile("file")
+			if err != nil {
+				t.Errorf("Failed to read uploaded file: %v", err)
+				return
+			}
+			stored, _ = io.ReadAll(part)
+			storedName = header.Filename
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "123", "filename": storedName, "fileSize": len(stored)})
+			return
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, storedName))
+		w.Write(stored)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	localPath := dir + "/secret.txt"
+	if err := os.WriteFile(localPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	key, err := envelope.NewRawKey(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewClientWithConfig(server.URL, "")
+	c.Progress = io.Discard
+	c.Encryption = key
+	c.EncryptUploads = true
+	if err := c.UploadFile("/api/files/upload", localPath, "", "", nil); err != nil {
+		t.Fatalf("Client.UploadFile() error = %v", err)
+	}
+	if storedName != "secret.txt"+envelope.Suffix {
+		t.Errorf("Expected stored name secret.txt.enc, got %q", storedName)
+	}
+	if !envelope.IsEnvelope(stored) || bytes.Contains(stored, []byte("confidential")) {
+		t.Fatal("Expected the server to receive only ciphertext")
+	}
+	if int64(len(stored)) != c.UploadSize(int64(len(content))) {
+		t.Errorf("Expected %d stored bytes, got %d", c.UploadSize(int64(len(content))), len(stored))
+	}
+
+	// Downloads decrypt transparently and drop the suffix from the saved name
+	outDir := t.TempDir()
+	filePath, err := c.DownloadFile("/api/files/123/download", outDir)
+	if err != nil {
+		t.Fatalf("Client.DownloadFile() error = %v", err)
+	}
+	if filePath != outDir+"/secret.txt" {
+		t.Errorf("Expected %s/secret.txt, got %s", outDir, filePath)
+	}
+	if got, _ := os.ReadFile(filePath); string(got) != content {
+		t.Error("Decrypted content does not match the original")
+	}
+
+	// A wrong key fails without leaving anything behind
+	wrongKey, _ := envelope.NewRawKey(bytes.Repeat([]byte{8}, 32))
+	c.Encryption = wrongKey
+	outDir = t.TempDir()
+	if _, err := c.DownloadFile("/api/files/123/download", outDir); !errors.Is(err, envelope.ErrWrongKey) {
+		t.Errorf("Expected ErrWrongKey, got %v", err)
+	}
+	if entries, _ := os.ReadDir(outDir); len(entries) != 0 {
+		t.Errorf("Expected no files after a failed decryption, found %d", len(entries))
+	}
+
+	// Without a key, encrypted files are refused rather than saved as ciphertext
+	c.Encryption = nil
+	if _, err := c.DownloadFile("/api/files/123/download", t.TempDir()); err == nil {
+		t.Error("Expected an error downloading an encrypted file without a key")
+	}
+}
+
+func TestClient_DownloadFileDigest_Encrypted(t *testing.T) {
+	content := strings.Repeat("confidential ", 100)
+	key, err := envelope.NewRawKey(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypter, err := envelope.NewEncryptReader(strings.NewReader(content), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := io.ReadAll(encrypter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotRange string
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		gotRange = r.Header.Get("Range")
+		var start int
+		if gotRange != "" {
+			fmt.Sscanf(gotRange, "bytes=%d-", &start)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(stored)-1, len(stored)))
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		w.Write(stored[start:])
+	})
+	defer server.Close()
+
+	// A partial file holding the start of the ciphertext is not resumed
+	outputPath := t.TempDir() + "/secret.txt"
+	if err := os.WriteFile(outputPath+partSuffix, stored[:100], 0644); err != nil {
+		t.Fatalf("Failed to create partial file: %v", err)
+	}
+
+	c := NewClientWithConfig(server.URL, "")
+	c.Progress = io.Discard
+	c.Encryption = key
+	storedSum := sha256.Sum256(stored)
+	opts := DownloadOptions{Resume: true, Checksum: hex.EncodeToString(storedSum[:])}
+	filePath, digest, err := c.DownloadFileDigest(context.Background(), "/api/files/123/download", outputPath, opts)
+	if err != nil {
+		t.Fatalf("DownloadFileDigest() error = %v", err)
+	}
+	if gotRange != "" {
+		t.Errorf("Expected the encrypted download to start over, got Range %q", gotRange)
+	}
+	if got, _ := os.ReadFile(filePath); string(got) != content {
+		t.Error("Decrypted content does not match the original")
+	}
+	if _, err := os.Stat(outputPath + partSuffix); !os.IsNotExist(err) {
+		t.Error("Expected the stale .part file to be removed")
+	}
+
+	// The digest describes the plaintext on disk, after the ciphertext was verified
+	plainSum := sha256.Sum256([]byte(content))
+	if !digest.Decrypted || !digest.Verified || digest.Size != int64(len(content)) || digest.SHA256 != hex.EncodeToString(plainSum[:]) {
+		t.Errorf("Expected a verified plaintext digest, got %+v", digest)
+	}
+}
+
+func TestClient_ContextCancellation(t *testing.T) {
+	release := make(chan struct{})
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	defer server.Close()
+	defer close(release)
+
+	client := NewClientWithConfig(server.URL, "")
+
+	// A per-call deadline stops a request that never answers
+	client.Timeout = 50 * time.Millisecond
+	if err := client.GetContext(context.Background(), "/api/slow", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+
+	// An already cancelled context fails immediately
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.Timeout = 0
+	if err := client.GetContext(ctx, "/api/slow", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context canceled, got %v", err)
+	}
+}
+
+func TestClient_DownloadFileContext_CleansUpOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000")
+		w.Write([]byte("first chunk"))
+		w.(http.Flusher).Flush()
+		cancel()
+		<-r.Context().Done()
+	})
+	defer server.Close()
+
+	outputPath := t.TempDir() + "/test.txt"
+	client := NewClientWithConfig(server.URL, "")
+	client.Progress = io.Discard
+
+	_, err := client.DownloadFileContext(ctx, "/api/files/123/download", outputPath, DownloadOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context canceled, got %v", err)
+	}
+	if _, err := os.Stat(outputPath + partSuffix); !os.IsNotExist(err) {
+		t.Error("Expected .part file to be removed after cancellation")
+	}
+}
+
 func TestClient_ErrorHandling(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,51 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CopyFileContext copies the file with the given ID to folderPath/filename
+// The API has no copy endpoint, so the download is streamed straight into a
+// new upload without touching the local disk. The upload is not retried
+// because the download stream cannot be rewound.
+func (c *Client) CopyFileContext(ctx context.Context, id string, folderPath string, filename string, result interface{}) error {
+	ctx, cancel := withTimeout(ctx, c.TransferTimeout)
+	defer cancel()
+
+	fullURL, err := c.buildURL(fmt.Sprintf("/api/files/%s/download", id))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.requestDownload(ctx, fullURL, 0)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return c.parseErrorResponse(resp, http.MethodGet, fullURL)
+	}
+
+	if _, err := c.uploadStream(ctx, "/api/files/upload", resp.Body, resp.ContentLength, filename, folderPath, filename, result); err != nil {
+		return fmt.Errorf("copy failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+)
+
+func TestClient_CopyFileContext(t *testing.T) {
+	content := strings.Repeat("copy me ", 1000)
+
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/files/src-id/download":
+			w.Header().Set("Content-Length", "8000")
+			io.WriteString(w, content)
+		case "/api/files/upload":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Errorf("Failed to parse multipart form: %v", err)
+				return
+			}
+			if got := r.FormValue("folderPath"); got != "/backup" {
+				t.Errorf("Expected folderPath /backup, got %q", got)
+			}
+			if got := r.FormValue("filename"); got != "copy.txt" {
+				t.Errorf("Expected filename copy.txt, got %q", got)
+			}
+			part, _, err := r.FormFile("file")
+			if err != nil {
+				t.Errorf("Missing file part: %v", err)
+				return
+			}
+			data, _ := io.ReadAll(part)
+			if string(data) != content {
+				t.Errorf("Uploaded %d bytes, want the %d downloaded", len(data), len(content))
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(file.FileResponse{ID: "new-id", Filename: "copy.txt"})
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	c := NewClientWithConfig(server.URL, "")
+	c.Progress = io.Discard
+	var result file.FileResponse
+	if err := c.CopyFileContext(context.Background(), "src-id", "/backup", "copy.txt", &result); err != nil {
+		t.Fatalf("CopyFileContext() error = %v", err)
+	}
+	if result.ID != "new-id" {
+		t.Errorf("Expected new-id, got %q", result.ID)
+	}
+}
+
+func TestClient_CopyFileContext_SourceMissing(t *testing.T) {
+	uploaded := false
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/files/upload" {
+			uploaded = true
+		}
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "File not found"})
+	})
+	defer server.Close()
+
+	c := NewClientWithConfig(server.URL, "")
+	if err := c.CopyFileContext(context.Background(), "missing", "/", "a.txt", nil); err == nil {
+		t.Fatal("CopyFileContext() expected error, got nil")
+	}
+	if uploaded {
+		t.Error("Upload was attempted for a missing source")
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+)
+
+// HasGlob reports whether a remote path contains glob metacharacters
+func HasGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// RemoteFilePath returns the full remote path of f, e.g. /photos/2024/a.jpg
+func RemoteFilePath(f *file.FileResponse) string {
+	return strings.TrimSuffix(FileFolder(f), "/") + "/" + f.Filename
+}
+
+// GlobBase returns the longest folder of pattern that contains no glob
+// metacharacters; every match lies beneath it
+// Examples: "/photos/**/*.jpg" -> "/photos", "/logs/2024-*.gz" -> "/logs"
+func GlobBase(pattern string) string {
+	segments := splitRemotePath(pattern)
+	if len(segments) > 0 {
+		segments = segments[:len(segments)-1]
+	}
+	var base []string
+	for _, segment := range segments {
+		if HasGlob(segment) {
+			break
+		}
+		base = append(base, segment)
+	}
+	return "/" + strings.Join(base, "/")
+}
+
+// ValidateGlob checks that every segment of a remote glob is well-formed
+func ValidateGlob(pattern string) error {
+	segments := splitRemotePath(pattern)
+	if len(segments) == 0 {
+		return fmt.Errorf("invalid pattern %q: must end with a filename pattern", pattern)
+	}
+	for _, segment := range segments {
+		if segment == ".." {
+			return fmt.Errorf("invalid pattern %q: cannot contain '..'", pattern)
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	if segments[len(segments)-1] == "**" {
+		return fmt.Errorf("invalid pattern %q: must end with a filename pattern", pattern)
+	}
+	return nil
+}
+
+// MatchRemotePath reports whether a remote file path matches pattern
+// Segments are matched with path.Match, and a "**" segment matches any number
+// of folders (including none). Patterns without a leading "/" are relative to
+// the root folder.
+func MatchRemotePath(pattern, name string) bool {
+	return matchSegments(splitRemotePath(pattern), splitRemotePath(name))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+func splitRemotePath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// ExpandGlob returns the files whose folder path and filename match pattern,
+// sorted by path
+// Only the folder the pattern is rooted at (see GlobBase) is listed when the
+// folder part has no wildcards; otherwise every folder beneath it is walked.
+func (c *Client) ExpandGlob(ctx context.Context, pattern string) ([]file.FileResponse, error) {
+	if err := ValidateGlob(pattern); err != nil {
+		return nil, err
+	}
+
+	base := GlobBase(pattern)
+	var candidates []file.FileResponse
+	var err error
+	if len(splitRemotePath(base))+1 == len(splitRemotePath(pattern)) {
+		// Only the filename has wildcards; the root folder has no folderPath filter
+		listFolder := base
+		if base == "/" {
+			listFolder = ""
+		}
+		candidates, err = c.ListFolderFiles(ctx, listFolder)
+	} else {
+		candidates, err = c.ListFolderTree(ctx, base)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []file.FileResponse
+	for _, f := range candidates {
+		if MatchRemotePath(pattern, RemoteFilePath(&f)) {
+			matches = append(matches, f)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return RemoteFilePath(&matches[i]) < RemoteFilePath(&matches[j])
+	})
+	return matches, nil
+}
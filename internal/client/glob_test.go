@@ -0,0 +1,120 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+)
+
+func TestMatchRemotePath(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"/logs/2024-*.gz", "/logs/2024-01.gz", true},
+		{"/logs/2024-*.gz", "/logs/2023-01.gz", false},
+		{"/logs/2024-*.gz", "/logs/old/2024-01.gz", false},
+		{"/photos/**/*.jpg", "/photos/a.jpg", true},
+		{"/photos/**/*.jpg", "/photos/2024/summer/a.jpg", true},
+		{"/photos/**/*.jpg", "/photos/2024/a.png", false},
+		{"/photos/**/*.jpg", "/other/a.jpg", false},
+		{"/**", "/a/b/c.txt", true},
+		{"/tmp/*", "/tmp/a.txt", true},
+		{"/tmp/*", "/tmp/sub/a.txt", false},
+		{"*.txt", "/a.txt", true},
+		{"/?/[ab].txt", "/x/b.txt", true},
+	}
+	for _, tt := range tests {
+		if got := MatchRemotePath(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("MatchRemotePath(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGlobBase(t *testing.T) {
+	tests := map[string]string{
+		"/photos/**/*.jpg":   "/photos",
+		"/logs/2024-*.gz":    "/logs",
+		"/a/b/c*/d.txt":      "/a/b",
+		"*.txt":              "/",
+		"/*/2024/*.jpg":      "/",
+		"/archive/2024/x?.z": "/archive/2024",
+	}
+	for pattern, want := range tests {
+		if got := GlobBase(pattern); got != want {
+			t.Errorf("GlobBase(%q) = %q, want %q", pattern, got, want)
+		}
+	}
+}
+
+func TestValidateGlob(t *testing.T) {
+	for _, pattern := range []string{"/logs/*.gz", "/photos/**/*.jpg", "*"} {
+		if err := ValidateGlob(pattern); err != nil {
+			t.Errorf("ValidateGlob(%q) error = %v", pattern, err)
+		}
+	}
+	for _, pattern := range []string{"/", "/logs/[a-", "/photos/**", "/../*"} {
+		if err := ValidateGlob(pattern); err == nil {
+			t.Errorf("ValidateGlob(%q) expected error, got nil", pattern)
+		}
+	}
+}
+
+func TestClient_ExpandGlob(t *testing.T) {
+	folders := map[string][]string{
+		"/photos":      {"/photos/2024"},
+		"/photos/2024": {"/photos/2024/summer"},
+	}
+	files := []file.FileResponse{
+		{ID: "1", Filename: "b.jpg", FolderPath: ptrString("/photos")},
+		{ID: "2", Filename: "a.jpg", FolderPath: ptrString("/photos/2024/summer")},
+		{ID: "3", Filename: "a.png", FolderPath: ptrString("/photos/2024")},
+		{ID: "4", Filename: "c.jpg", FolderPath: ptrString("/other")},
+	}
+	c := newRemoteTestServer(t, folders, files)
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"/photos/**/*.jpg", []string{"2", "1"}},
+		{"/photos/*.jpg", []string{"1"}},
+		{"/photos/2024/*", []string{"3"}},
+		{"/**/*.jpg", []string{"4", "2", "1"}},
+		{"/photos/*.gif", nil},
+	}
+	for _, tt := range tests {
+		matches, err := c.ExpandGlob(context.Background(), tt.pattern)
+		if err != nil {
+			t.Fatalf("ExpandGlob(%q) error = %v", tt.pattern, err)
+		}
+		var got []string
+		for _, f := range matches {
+			got = append(got, f.ID)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ExpandGlob(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func ptrString(s string) *string {
+	return &s
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+)
+
+// FilePager walks a paginated file listing one file at a time, fetching a
+// page only when the previous one has been consumed
+//
+//	pager := apiClient.NewFilePager("/api/files", params)
+//	for pager.Next(ctx) {
+//		f := pager.File()
+//	}
+//	if err := pager.Err(); err != nil { ... }
+type FilePager struct {
+	client   *Client
+	path     string
+	params   url.Values
+	pageSize int
+
+	page    int
+	done    bool
+	buffer  []file.FileResponse
+	current file.FileResponse
+	err     error
+
+	// TotalElements is the total reported by the first page (0 until it is fetched)
+	TotalElements int64
+}
+
+// NewFilePager creates a pager over a PageResponse endpoint such as /api/files
+// or /api/files/search. params holds the filters; page and size are managed by
+// the pager, starting at page 0 with the largest page size the API allows.
+func (c *Client) NewFilePager(path string, params url.Values) *FilePager {
+	copied := url.Values{}
+	for k, v := range params {
+		copied[k] = append([]string(nil), v...)
+	}
+	return &FilePager{
+		client:   c,
+		path:     path,
+		params:   copied,
+		pageSize: listPageSize,
+	}
+}
+
+// SetPageSize changes the number of files fetched per request
+// It must be called before the first call to Next.
+func (p *FilePager) SetPageSize(size int) {
+	if size > 0 {
+		p.pageSize = size
+	}
+}
+
+// Next advances to the next file, fetching the next page when needed
+// It returns false when the listing is exhausted or a request fails.
+func (p *FilePager) Next(ctx context.Context) bool {
+	for len(p.buffer) == 0 {
+		if p.done || p.err != nil {
+			return false
+		}
+		pageResp, err := p.NextPage(ctx)
+		if err != nil {
+			return false
+		}
+		p.buffer = pageResp.Content
+	}
+
+	p.current = p.buffer[0]
+	p.buffer = p.buffer[1:]
+	return true
+}
+
+// File returns the file the pager is positioned on
+func (p *FilePager) File() file.FileResponse {
+	return p.current
+}
+
+// Err returns the error that stopped the pager, if any
+func (p *FilePager) Err() error {
+	return p.err
+}
+
+// NextPage fetches the next page directly; use either NextPage or Next, not both
+// It returns nil and no error once the last page has been returned.
+func (p *FilePager) NextPage(ctx context.Context) (*file.PageResponse, error) {
+	if p.done || p.err != nil {
+		return nil, p.err
+	}
+
+	params := url.Values{}
+	for k, v := range p.params {
+		params[k] = v
+	}
+	params.Set("page", strconv.Itoa(p.page))
+	params.Set("size", strconv.Itoa(p.pageSize))
+
+	var pageResp file.PageResponse
+	if err := p.client.GetContext(ctx, p.path+"?"+params.Encode(), &pageResp); err != nil {
+		p.err = err
+		return nil, err
+	}
+
+	if p.page == 0 {
+		p.TotalElements = pageResp.TotalElements
+	}
+	p.page++
+
+	// Stop on the last page, on an empty page, or once TotalPages is reached,
+	// so a server that ignores the page parameter cannot loop forever
+	if pageResp.Last || len(pageResp.Content) == 0 || (pageResp.TotalPages > 0 && p.page >= pageResp.TotalPages) {
+		p.done = true
+	}
+	return &pageResp, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+)
+
+// newPagedTestServer serves total files in pages of the requested size
+func newPagedTestServer(t *testing.T, total int, requests *int) *Client {
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.URL.Query().Get("q") != "report" {
+			t.Errorf("Expected filter q=report to be kept, got %q", r.URL.RawQuery)
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+
+		start, end := page*size, page*size+size
+		if start > total {
+			start = total
+		}
+		if end > total {
+			end = total
+		}
+		var content []file.FileResponse
+		for i := start; i < end; i++ {
+			content = append(content, file.FileResponse{ID: fmt.Sprintf("file-%d", i)})
+		}
+		json.NewEncoder(w).Encode(file.PageResponse{
+			Content:       content,
+			TotalElements: int64(total),
+			TotalPages:    (total + size - 1) / size,
+			Last:          end >= total,
+		})
+	})
+	t.Cleanup(server.Close)
+	return NewClientWithConfig(server.URL, "")
+}
+
+func TestFilePager_WalksAllPages(t *testing.T) {
+	requests := 0
+	c := newPagedTestServer(t, 7, &requests)
+
+	pager := c.NewFilePager("/api/files/search", url.Values{"q": {"report"}})
+	pager.SetPageSize(3)
+
+	var ids []string
+	for pager.Next(context.Background()) {
+		ids = append(ids, pager.File().ID)
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatalf("Pager error: %v", err)
+	}
+
+	if len(ids) != 7 || ids[0] != "file-0" || ids[6] != "file-6" {
+		t.Errorf("Unexpected files: %v", ids)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 page requests, got %d", requests)
+	}
+	if pager.TotalElements != 7 {
+		t.Errorf("Expected TotalElements 7, got %d", pager.TotalElements)
+	}
+}
+
+func TestFilePager_FetchesLazily(t *testing.T) {
+	requests := 0
+	c := newPagedTestServer(t, 250, &requests)
+
+	pager := c.NewFilePager("/api/files", url.Values{"q": {"report"}})
+	for i := 0; i < 5; i++ {
+		if !pager.Next(context.Background()) {
+			t.Fatalf("Next() returned false after %d files: %v", i, pager.Err())
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected only the first page to be fetched, got %d requests", requests)
+	}
+}
+
+func TestFilePager_StopsWhenServerIgnoresPage(t *testing.T) {
+	requests := 0
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// Always returns the same non-last page
+		json.NewEncoder(w).Encode(file.PageResponse{
+			Content:    []file.FileResponse{{ID: "a"}, {ID: "b"}},
+			TotalPages: 2,
+		})
+	})
+	defer server.Close()
+
+	pager := NewClientWithConfig(server.URL, "").NewFilePager("/api/files", nil)
+	count := 0
+	for pager.Next(context.Background()) {
+		count++
+	}
+	if count != 4 || requests != 2 {
+		t.Errorf("Expected 4 files from 2 requests, got %d files from %d requests", count, requests)
+	}
+}
+
+func TestFilePager_Error(t *testing.T) {
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"message": "bad request"})
+	})
+	defer server.Close()
+
+	pager := NewClientWithConfig(server.URL, "").NewFilePager("/api/files", nil)
+	if pager.Next(context.Background()) {
+		t.Error("Expected Next to return false on error")
+	}
+	if pager.Err() == nil {
+		t.Error("Expected pager error, got nil")
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+)
+
+const (
+	// listPageSize is the page size used when walking listings (API maximum)
+	listPageSize = 100
+)
+
+// FileFolder returns the folder path of f, using "/" for files in the root folder
+func FileFolder(f *file.FileResponse) string {
+	if f.FolderPath == nil || *f.FolderPath == "" {
+		return "/"
+	}
+	return *f.FolderPath
+}
+
+// ListFolderFiles returns every file stored directly in folderPath, following pagination
+// An empty folderPath lists all files.
+func (c *Client) ListFolderFiles(ctx context.Context, folderPath string) ([]file.FileResponse, error) {
+	params := url.Values{}
+	if folderPath != "" {
+		params.Set("folderPath", folderPath)
+	}
+
+	var files []file.FileResponse
+	pager := c.NewFilePager("/api/files", params)
+	for pager.Next(ctx) {
+		f := pager.File()
+		// The API may include files from subfolders; keep only direct children
+		if folderPath == "" || FileFolder(&f) == folderPath {
+			files = append(files, f)
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list files in %s: %w", folderPath, err)
+	}
+	return files, nil
+}
+
+// ListFolders returns the folders below parentPath (all folders if parentPath is empty)
+func (c *Client) ListFolders(ctx context.Context, parentPath string) ([]file.FolderResponse, error) {
+	path := "/api/folders"
+	if parentPath != "" {
+		params := url.Values{}
+		params.Set("parentPath", parentPath)
+		path += "?" + params.Encode()
+	}
+
+	var folders []file.FolderResponse
+	if err := c.GetContext(ctx, path, &folders); err != nil {
+		return nil, fmt.Errorf("failed to list folders in %s: %w", parentPath, err)
+	}
+	return folders, nil
+}
+
+// WalkFolders returns root and every folder beneath it, sorted by path
+func (c *Client) WalkFolders(ctx context.Context, root string) ([]string, error) {
+	seen := map[string]bool{root: true}
+	queue := []string{root}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+
+		folders, err := c.ListFolders(ctx, parent)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range folders {
+			if seen[f.Path] || !IsUnderFolder(f.Path, parent) {
+				continue
+			}
+			seen[f.Path] = true
+			queue = append(queue, f.Path)
+		}
+	}
+
+	paths := make([]string, 0, len(seen))
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// ListFolderTree returns every file in root and its subfolders
+func (c *Client) ListFolderTree(ctx context.Context, root string) ([]file.FileResponse, error) {
+	// Everything lives under the root folder, so a single listing suffices
+	if root == "/" {
+		return c.ListFolderFiles(ctx, "")
+	}
+
+	folders, err := c.WalkFolders(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []file.FileResponse
+	for _, folder := range folders {
+		folderFiles, err := c.ListFolderFiles(ctx, folder)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, folderFiles...)
+	}
+	return files, nil
+}
+
+// IsUnderFolder reports whether path is strictly below folder
+func IsUnderFolder(path, folder string) bool {
+	if folder == "/" {
+		return path != "/" && strings.HasPrefix(path, "/")
+	}
+	return strings.HasPrefix(path, strings.TrimSuffix(folder, "/")+"/")
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+)
+
+// newRemoteTestServer serves a fixed folder tree, paging file listings two at a time
+func newRemoteTestServer(t *testing.T, folders map[string][]string, files []file.FileResponse) *Client {
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/folders":
+			var resp []file.FolderResponse
+			for _, p := range folders[r.URL.Query().Get("parentPath")] {
+				resp = append(resp, file.FolderResponse{Path: p})
+			}
+			json.NewEncoder(w).Encode(resp)
+		case "/api/files":
+			folder := r.URL.Query().Get("folderPath")
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			var matching []file.FileResponse
+			for _, f := range files {
+				if folder == "" || FileFolder(&f) == folder {
+					matching = append(matching, f)
+				}
+			}
+			start, end := page*2, page*2+2
+			if start > len(matching) {
+				start = len(matching)
+			}
+			if end > len(matching) {
+				end = len(matching)
+			}
+			json.NewEncoder(w).Encode(file.PageResponse{
+				Content: matching[start:end],
+				Last:    end >= len(matching),
+			})
+		default:
+			t.Errorf("Unexpected request: %s", r.URL)
+		}
+	})
+	t.Cleanup(server.Close)
+	return NewClientWithConfig(server.URL, "")
+}
+
+func folderFile(id, folder string) file.FileResponse {
+	return file.FileResponse{ID: id, Filename: id + ".txt", FolderPath: &folder}
+}
+
+func TestClient_ListFolderTree(t *testing.T) {
+	folders := map[string][]string{
+		"/photos":      {"/photos/2023", "/photos/2024"},
+		"/photos/2024": {"/photos/2024/summer"},
+	}
+	files := []file.FileResponse{
+		folderFile("a", "/photos"),
+		folderFile("b", "/photos"),
+		folderFile("c", "/photos"),
+		folderFile("d", "/photos/2024/summer"),
+		folderFile("e", "/other"),
+	}
+	c := newRemoteTestServer(t, folders, files)
+
+	paths, err := c.WalkFolders(context.Background(), "/photos")
+	if err != nil {
+		t.Fatalf("WalkFolders() error = %v", err)
+	}
+	if len(paths) != 4 {
+		t.Errorf("Expected 4 folders, got %v", paths)
+	}
+
+	tree, err := c.ListFolderTree(context.Background(), "/photos")
+	if err != nil {
+		t.Fatalf("ListFolderTree() error = %v", err)
+	}
+	if len(tree) != 4 {
+		t.Errorf("Expected 4 files across pages and subfolders, got %d", len(tree))
+	}
+	for _, f := range tree {
+		if f.ID == "e" {
+			t.Error("Expected files outside the tree to be excluded")
+		}
+	}
+}
+
+func TestIsUnderFolder(t *testing.T) {
+	tests := []struct {
+		path, folder string
+		want         bool
+	}{
+		{"/photos/2024", "/photos", true},
+		{"/photos-old", "/photos", false},
+		{"/photos", "/photos", false},
+		{"/photos", "/", true},
+		{"/", "/", false},
+	}
+	for _, tt := range tests {
+		if got := IsUnderFolder(tt.path, tt.folder); got != tt.want {
+			t.Errorf("IsUnderFolder(%q, %q) = %v, want %v", tt.path, tt.folder, got, tt.want)
+		}
+	}
+}
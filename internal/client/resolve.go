@@ -0,0 +1,125 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/util"
+)
+
+// ErrFileNotFound is returned when no file exists at a remote path
+var ErrFileNotFound = errors.New("file not found")
+
+// AmbiguousPathError is returned when several files share a remote path
+type AmbiguousPathError struct {
+	Path    string
+	Matches []file.FileResponse
+}
+
+// Error implements the error interface
+func (e *AmbiguousPathError) Error() string {
+	ids := make([]string, len(e.Matches))
+	for i, f := range e.Matches {
+		ids[i] = f.ID
+	}
+	return fmt.Sprintf("path %s matches %d files (%s); use a file ID instead",
+		e.Path, len(e.Matches), strings.Join(ids, ", "))
+}
+
+// IsFileID reports whether identifier is a file ID (UUID) rather than a remote path
+func IsFileID(identifier string) bool {
+	return util.ValidateUUID(identifier) == nil
+}
+
+// SplitRemotePath splits a remote file path into its folder and filename
+// A path without a folder, such as "document.pdf", refers to the root folder.
+// Examples: "/photos/2024/a.jpg" -> ("/photos/2024", "a.jpg"), "a.jpg" -> ("/", "a.jpg")
+func SplitRemotePath(remotePath string) (folder, filename string, err error) {
+	remotePath = strings.TrimSpace(remotePath)
+	if remotePath == "" || strings.HasSuffix(remotePath, "/") {
+		return "", "", fmt.Errorf("invalid file path %q: must end with a filename", remotePath)
+	}
+	if !strings.HasPrefix(remotePath, "/") {
+		remotePath = "/" + remotePath
+	}
+	if err := util.ValidatePath(remotePath); err != nil {
+		return "", "", fmt.Errorf("invalid file path %q: %w", remotePath, err)
+	}
+	return path.Dir(remotePath), path.Base(remotePath), nil
+}
+
+// ResolveFile returns the file addressed by identifier, which is either a
+// file ID (UUID) or a remote path such as /folder/name.ext
+// Paths are resolved by listing the folder and matching the filename exactly;
+// an *AmbiguousPathError is returned if more than one file matches.
+func (c *Client) ResolveFile(ctx context.Context, identifier string) (*file.FileResponse, error) {
+	if IsFileID(identifier) {
+		var f file.FileResponse
+		if err := c.GetContext(ctx, fmt.Sprintf("/api/files/%s", identifier), &f); err != nil {
+			return nil, fmt.Errorf("failed to get file %s: %w", identifier, err)
+		}
+		return &f, nil
+	}
+
+	folder, filename, err := SplitRemotePath(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	// The root folder has no folderPath filter, so list everything and match on folder
+	listFolder := folder
+	if folder == "/" {
+		listFolder = ""
+	}
+	files, err := c.ListFolderFiles(ctx, listFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []file.FileResponse
+	for _, f := range files {
+		if f.Filename == filename && FileFolder(&f) == folder {
+			matches = append(matches, f)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrFileNotFound, identifier)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, &AmbiguousPathError{Path: identifier, Matches: matches}
+	}
+}
+
+// ResolveFileID returns the ID of the file addressed by identifier
+// File IDs are returned as-is without a request.
+func (c *Client) ResolveFileID(ctx context.Context, identifier string) (string, error) {
+	if IsFileID(identifier) {
+		return identifier, nil
+	}
+	f, err := c.ResolveFile(ctx, identifier)
+	if err != nil {
+		return "", err
+	}
+	return f.ID, nil
+}
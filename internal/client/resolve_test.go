@@ -0,0 +1,120 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/file"
+)
+
+func TestClient_ResolveFile(t *testing.T) {
+	root := ""
+	files := []file.FileResponse{
+		folderFile("a", "/docs"),
+		folderFile("b", "/docs"),
+		folderFile("b", "/docs"),
+		folderFile("a", "/docs/old"),
+		{ID: "r", Filename: "readme.md", FolderPath: &root},
+	}
+	c := newRemoteTestServer(t, nil, files)
+	ctx := context.Background()
+
+	tests := []struct {
+		path      string
+		wantID    string
+		wantErr   error
+		ambiguous bool
+	}{
+		{path: "/docs/a.txt", wantID: "a"},
+		{path: "/docs/old/a.txt", wantID: "a"},
+		{path: "/readme.md", wantID: "r"},
+		{path: "readme.md", wantID: "r"},
+		{path: "/docs/missing.txt", wantErr: ErrFileNotFound},
+		{path: "/docs/b.txt", ambiguous: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			f, err := c.ResolveFile(ctx, tt.path)
+			var ambiguous *AmbiguousPathError
+			switch {
+			case tt.ambiguous:
+				if !errors.As(err, &ambiguous) || len(ambiguous.Matches) != 2 {
+					t.Errorf("ResolveFile() error = %v, want AmbiguousPathError with 2 matches", err)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ResolveFile() error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("ResolveFile() error = %v", err)
+			case f.ID != tt.wantID:
+				t.Errorf("ResolveFile() = %s, want %s", f.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestClient_ResolveFile_ID(t *testing.T) {
+	const id = "550e8400-e29b-41d4-a716-446655440000"
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/files/"+id {
+			t.Errorf("Unexpected request: %s", r.URL)
+		}
+		json.NewEncoder(w).Encode(file.FileResponse{ID: id, Filename: "a.txt"})
+	})
+	defer server.Close()
+	c := NewClientWithConfig(server.URL, "")
+
+	f, err := c.ResolveFile(context.Background(), id)
+	if err != nil || f.Filename != "a.txt" {
+		t.Errorf("ResolveFile() = %+v, %v", f, err)
+	}
+
+	// IDs are passed through without a request
+	server.Close()
+	if got, err := c.ResolveFileID(context.Background(), id); err != nil || got != id {
+		t.Errorf("ResolveFileID() = %q, %v, want %q", got, err, id)
+	}
+}
+
+func TestSplitRemotePath(t *testing.T) {
+	tests := []struct {
+		input, folder, filename string
+		wantErr                 bool
+	}{
+		{"/photos/2024/a.jpg", "/photos/2024", "a.jpg", false},
+		{"/a.jpg", "/", "a.jpg", false},
+		{"a.jpg", "/", "a.jpg", false},
+		{"/photos/", "", "", true},
+		{"", "", "", true},
+		{"/photos/../a.jpg", "", "", true},
+	}
+	for _, tt := range tests {
+		folder, filename, err := SplitRemotePath(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SplitRemotePath(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if folder != tt.folder || filename != tt.filename {
+			t.Errorf("SplitRemotePath(%q) = %q, %q, want %q, %q", tt.input, folder, filename, tt.folder, tt.filename)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the default number of attempts per request (1 = no retries)
+	DefaultMaxAttempts = 4
+	// DefaultRetryBaseDelay is the default delay before the first retry
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	// DefaultRetryMaxDelay caps the delay between two attempts
+	DefaultRetryMaxDelay = 30 * time.Second
+)
+
+// RetryPolicy controls how transient failures are retried
+// Retries use exponential backoff with full jitter, and honour the
+// Retry-After header sent with 429 and 503 responses.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by new clients
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DefaultMaxAttempts,
+		BaseDelay:   DefaultRetryBaseDelay,
+		MaxDelay:    DefaultRetryMaxDelay,
+	}
+}
+
+// attempts returns the number of attempts allowed by the policy (at least 1)
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns the delay before retry number attempt (1-based)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	// Full jitter spreads retries from parallel transfers apart
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// delay returns how long to wait before retry number attempt, preferring Retry-After when present
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp); ok {
+			if p.MaxDelay > 0 && d > p.MaxDelay {
+				return p.MaxDelay
+			}
+			return d
+		}
+	}
+	return p.backoff(attempt)
+}
+
+// isRetryableStatus reports whether a response status indicates a transient failure
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryableError reports whether a transport error is worth retrying
+// Connection resets and connections closed by the server mid-request are retried.
+func isRetryableError(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// isIdempotent reports whether a request with this method can be sent twice without side effects
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRejectedStatus reports whether a response status means the request was
+// turned away before being processed, so even a POST can be sent again
+func isRejectedStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests
+}
+
+// isNotSentError reports whether a transport error shows the request never
+// reached the server, because no connection could be made
+func isNotSentError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryAfter parses the Retry-After header, which is either seconds or an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		d := time.Until(when)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// do sends the request built by newRequest, retrying transient failures per c.Retry
+// newRequest is called once per attempt (starting at 0) so every attempt gets a fresh body.
+// When replayable is false the request is sent only once.
+// Requests with non-idempotent methods such as POST may already have been
+// applied when a gateway error or a dropped connection is seen, so they are
+// only retried when the server rejected them outright (429) or no connection
+// could be made.
+// The final response is returned as-is, including error statuses.
+// Waiting between attempts stops early if ctx is cancelled.
+func (c *Client) do(ctx context.Context, newRequest func(attempt int) (*http.Request, error), replayable bool) (*http.Response, error) {
+	attempts := c.Retry.attempts()
+	if !replayable {
+		attempts = 1
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest(attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		last := attempt+1 >= attempts
+		idempotent := isIdempotent(req.Method)
+		switch {
+		case err != nil:
+			retryable := isNotSentError(err) || (idempotent && isRetryableError(err))
+			if last || ctx.Err() != nil || !retryable {
+				return nil, err
+			}
+			c.logf("%s %s failed: %v", req.Method, req.URL, err)
+		case !last && (isRejectedStatus(resp.StatusCode) || (idempotent && isRetryableStatus(resp.StatusCode))):
+			c.logf("%s %s returned %s", req.Method, req.URL, resp.Status)
+			// Drain so the connection can be reused
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		wait := c.Retry.delay(attempt+1, resp)
+		c.logf("retrying in %s (attempt %d of %d)", wait.Round(time.Millisecond), attempt+2, attempts)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient creates a client with a fast retry policy for tests
+func newRetryTestClient(baseURL string) *Client {
+	c := NewClientWithConfig(baseURL, "")
+	c.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+	return c
+}
+
+func TestClient_RetryTransientStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		failStatus   int
+		failures     int32
+		wantErr      bool
+		wantAttempts int32
+	}{
+		{name: "503 then success", method: http.MethodPut, failStatus: http.StatusServiceUnavailable, failures: 1, wantAttempts: 2},
+		{name: "429 twice then success", method: http.MethodPut, failStatus: http.StatusTooManyRequests, failures: 2, wantAttempts: 3},
+		{name: "502 exhausts attempts", method: http.MethodPut, failStatus: http.StatusBadGateway, failures: 5, wantErr: true, wantAttempts: 3},
+		{name: "400 is not retried", method: http.MethodPut, failStatus: http.StatusBadRequest, failures: 5, wantErr: true, wantAttempts: 1},
+		{name: "POST is retried after 429", method: http.MethodPost, failStatus: http.StatusTooManyRequests, failures: 1, wantAttempts: 2},
+		{name: "POST is not retried after 504", method: http.MethodPost, failStatus: http.StatusGatewayTimeout, failures: 1, wantErr: true, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != `{"name":"test"}` {
+					t.Errorf("Attempt %d got body %q", attempts+1, body)
+				}
+				if atomic.AddInt32(&attempts, 1) <= tt.failures {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(tt.failStatus)
+					return
+				}
+				json.NewEncoder(w).Encode(map[string]string{"id": "123"})
+			})
+			defer server.Close()
+
+			var result map[string]string
+			c := newRetryTestClient(server.URL)
+			var err error
+			if tt.method == http.MethodPost {
+				err = c.Post("/api/test", map[string]string{"name": "test"}, &result)
+			} else {
+				err = c.Put("/api/test", map[string]string{"name": "test"}, &result)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%s error = %v, wantErr %v", tt.method, err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("Expected %d attempts, got %d", tt.wantAttempts, attempts)
+			}
+		})
+	}
+}
+
+func TestClient_UploadFile_RetryReplaysBody(t *testing.T) {
+	content := "replayable content"
+	var attempts int32
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("Failed to get file from form: %v", err)
+			return
+		}
+		defer f.Close()
+		data, _ := io.ReadAll(f)
+		if string(data) != content {
+			t.Errorf("Attempt %d uploaded %q, want %q", attempts+1, data, content)
+		}
+		// Uploads are POSTs, so only a rejected attempt is sent again
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"id": "123"})
+	})
+	defer server.Close()
+
+	tmpFile := t.TempDir() + "/test.txt"
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+
+	c := newRetryTestClient(server.URL)
+	progress := &countingProgress{}
+	c.Progress = progress
+	var result map[string]string
+	if err := c.UploadFile("/api/files/upload", tmpFile, "", "", &result); err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+	// The failed attempt's bytes are taken back, as a shared bar must not count them twice
+	if progress.n != int64(len(content)) {
+		t.Errorf("Expected %d bytes of progress, got %d", len(content), progress.n)
+	}
+}
+
+func TestClient_UploadFile_GatewayErrorNotRetried(t *testing.T) {
+	var attempts int32
+	server := setupTestServer(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	defer server.Close()
+
+	tmpFile := t.TempDir() + "/test.txt"
+	if err := os.WriteFile(tmpFile, []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+
+	c := newRetryTestClient(server.URL)
+	c.Progress = io.Discard
+	if err := c.UploadFile("/api/files/upload", tmpFile, "", "", nil); err == nil {
+		t.Fatal("Expected UploadFile() to fail")
+	}
+	// The server may have stored the file before the gateway gave up
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestClient_RetryWhenNotSent(t *testing.T) {
+	var attempts int32
+	c := newRetryTestClient("http://127.0.0.1:1")
+	c.HTTPClient.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&attempts, 1)
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	})
+	if err := c.Post("/api/test", nil, nil); err == nil {
+		t.Fatal("Expected Post() to fail")
+	}
+	// A POST that never reached the server is safe to send again
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+// countingProgress is a progress bar stand-in that accepts negative increments
+type countingProgress struct {
+	n int64
+}
+
+func (p *countingProgress) Write(b []byte) (int, error) {
+	p.n += int64(len(b))
+	return len(b), nil
+}
+
+func (p *countingProgress) Add64(n int64) error {
+	p.n += n
+	return nil
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/schollz/progressbar/v3"
+)
+
+const (
+	// DefaultConcurrency is the number of transfers run in parallel when not configured
+	DefaultConcurrency = 4
+)
+
+// TransferJob is a single unit of work run by a TransferScheduler
+// Size is the number of bytes the job is expected to transfer (-1 if unknown)
+type TransferJob struct {
+	Name string
+	Size int64
+	Run  func(ctx context.Context, c *Client) error
+}
+
+// TransferResult is the outcome of a single TransferJob
+type TransferResult struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// TransferScheduler runs transfer jobs on a bounded pool of workers
+// Progress is aggregated across all workers into a single progress bar,
+// and errors are collected per job instead of aborting the whole run.
+type TransferScheduler struct {
+	client       *Client
+	concurrency  int
+	description  string
+	showProgress bool
+}
+
+// NewTransferScheduler creates a scheduler that runs at most concurrency jobs at once
+// A concurrency below 1 falls back to DefaultConcurrency
+func NewTransferScheduler(c *Client, concurrency int) *TransferScheduler {
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+	return &TransferScheduler{
+		client:       c,
+		concurrency:  concurrency,
+		description:  "Transferring",
+		showProgress: true,
+	}
+}
+
+// SetDescription sets the label shown on the aggregate progress bar
+func (s *TransferScheduler) SetDescription(description string) {
+	s.description = description
+}
+
+// SetShowProgress enables or disables the aggregate progress bar
+func (s *TransferScheduler) SetShowProgress(show bool) {
+	s.showProgress = show
+}
+
+// Run executes jobs and returns one result per job, in the same order as jobs
+// Once ctx is cancelled no further jobs are started; jobs that never ran
+// report the context error.
+func (s *TransferScheduler) Run(ctx context.Context, jobs []TransferJob) []TransferResult {
+	results := make([]TransferResult, len(jobs))
+	if len(jobs) == 0 {
+		return results
+	}
+
+	// Every worker shares the same underlying HTTP client but reports
+	// bytes to the aggregate progress bar instead of drawing its own
+	worker := *s.client
+	var bar *progressbar.ProgressBar
+	if s.showProgress {
+		bar = progressbar.DefaultBytes(totalTransferSize(jobs), s.description)
+		worker.Progress = bar
+	} else {
+		worker.Progress = discardProgress{}
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	concurrency := s.concurrency
+	if concurrency > len(jobs) {
+		concurrency = len(jobs)
+	}
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = runTransferJob(ctx, &worker, jobs[i])
+			}
+		}()
+	}
+
+	// Dispatch jobs until they run out or the context is cancelled
+	next := 0
+dispatch:
+	for ; next < len(jobs); next++ {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case indexes <- next:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i := next; i < len(jobs); i++ {
+		results[i] = TransferResult{
+			Name: jobs[i].Name,
+			Err:  fmt.Errorf("not started: %w", ctx.Err()),
+		}
+	}
+
+	if bar != nil {
+		bar.Close()
+	}
+
+	return results
+}
+
+// runTransferJob runs a single job and records its outcome
+func runTransferJob(ctx context.Context, c *Client, job TransferJob) TransferResult {
+	start := time.Now()
+	var err error
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		err = fmt.Errorf("not started: %w", ctxErr)
+	} else {
+		err = job.Run(ctx, c)
+	}
+	return TransferResult{
+		Name:     job.Name,
+		Err:      err,
+		Duration: time.Since(start),
+	}
+}
+
+// totalTransferSize sums job sizes for the progress bar
+// It returns -1, which shows an indeterminate bar, when any size is unknown or
+// nothing is left to measure; the bar rejects a total of 0.
+func totalTransferSize(jobs []TransferJob) int64 {
+	var total int64
+	for _, job := range jobs {
+		if job.Size < 0 {
+			return -1
+		}
+		total += job.Size
+	}
+	if total == 0 {
+		return -1
+	}
+	return total
+}
+
+// FailedTransfers returns the results that ended with an error
+func FailedTransfers(results []TransferResult) []TransferResult {
+	var failed []TransferResult
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// discardProgress swallows progress updates when the aggregate bar is disabled
+type discardProgress struct{}
+
+func (discardProgress) Write(p []byte) (int, error) {
+	return len(p), nil
+}
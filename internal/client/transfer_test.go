@@ -0,0 +1,138 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransferScheduler_BoundedConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	jobs := make([]TransferJob, 10)
+	for i := range jobs {
+		jobs[i] = TransferJob{
+			Name: fmt.Sprintf("job-%d", i),
+			Run: func(ctx context.Context, c *Client) error {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return nil
+			},
+		}
+	}
+
+	scheduler := NewTransferScheduler(NewClientWithConfig("http://localhost", ""), 3)
+	scheduler.SetShowProgress(false)
+	results := scheduler.Run(context.Background(), jobs)
+
+	if len(results) != len(jobs) {
+		t.Fatalf("Expected %d results, got %d", len(jobs), len(results))
+	}
+	if maxRunning > 3 {
+		t.Errorf("Expected at most 3 concurrent jobs, got %d", maxRunning)
+	}
+	if failed := FailedTransfers(results); len(failed) != 0 {
+		t.Errorf("Expected no failures, got %d", len(failed))
+	}
+}
+
+func TestTransferScheduler_CollectsErrors(t *testing.T) {
+	errBoom := errors.New("boom")
+	jobs := []TransferJob{
+		{Name: "ok", Run: func(ctx context.Context, c *Client) error { return nil }},
+		{Name: "bad", Run: func(ctx context.Context, c *Client) error { return errBoom }},
+		{Name: "ok-too", Run: func(ctx context.Context, c *Client) error { return nil }},
+	}
+
+	scheduler := NewTransferScheduler(NewClientWithConfig("http://localhost", ""), 2)
+	scheduler.SetShowProgress(false)
+	results := scheduler.Run(context.Background(), jobs)
+
+	for i, job := range jobs {
+		if results[i].Name != job.Name {
+			t.Errorf("Expected result %d to be %q, got %q", i, job.Name, results[i].Name)
+		}
+	}
+	failed := FailedTransfers(results)
+	if len(failed) != 1 || !errors.Is(failed[0].Err, errBoom) {
+		t.Errorf("Expected only the failing job to be reported, got %+v", failed)
+	}
+}
+
+func TestTransferScheduler_Cancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var started int32
+	jobs := make([]TransferJob, 5)
+	for i := range jobs {
+		jobs[i] = TransferJob{
+			Name: fmt.Sprintf("job-%d", i),
+			Run: func(ctx context.Context, c *Client) error {
+				atomic.AddInt32(&started, 1)
+				cancel()
+				return nil
+			},
+		}
+	}
+
+	scheduler := NewTransferScheduler(NewClientWithConfig("http://localhost", ""), 1)
+	scheduler.SetShowProgress(false)
+	results := scheduler.Run(ctx, jobs)
+
+	if started != 1 {
+		t.Errorf("Expected exactly 1 job to start, got %d", started)
+	}
+	for _, r := range results[1:] {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("Expected %s to be cancelled, got %v", r.Name, r.Err)
+		}
+	}
+}
+
+func TestTotalTransferSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		sizes []int64
+		want  int64
+	}{
+		{"known sizes", []int64{10, 20}, 30},
+		{"empty file counts as zero", []int64{10, 0, 20}, 30},
+		{"unknown size", []int64{10, -1}, -1},
+		{"nothing to measure", []int64{0, 0}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := make([]TransferJob, len(tt.sizes))
+			for i, size := range tt.sizes {
+				jobs[i].Size = size
+			}
+			if got := totalTransferSize(jobs); got != tt.want {
+				t.Errorf("totalTransferSize() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,22 +16,36 @@ limitations under the License.
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
 // Config represents the CLI configuration
+// APIKey is resolved from the credential store of the active profile
 type Config struct {
-	APIURL string `mapstructure:"api_url" yaml:"api_url"`
-	APIKey string `mapstructure:"api_key" yaml:"api_key"`
+	APIURL          string `mapstructure:"api_url" yaml:"api_url"`
+	APIKey          string `mapstructure:"api_key" yaml:"api_key"`
+	Profile         string `mapstructure:"-" yaml:"-"`
+	CredentialStore string `mapstructure:"-" yaml:"-"`
 }
 
 var (
 	viperInstance *viper.Viper
 	configPath    string
+
+	// profileOverride is the profile selected with the --profile flag
+	profileOverride string
+
+	// apiURLOverride is the API URL given with the --api-url flag
+	apiURLOverride string
 )
 
 var (
@@ -45,9 +59,28 @@ const (
 	configFileName = "config.yaml"
 	DefaultAPIURL  = "http://localhost:8080" // Fallback if BuildTimeAPIURL is not set at compile time
 	envVarPrefix   = "CLOUD_STORAGE"
+
+	// DefaultProfile is used when no profile has been selected
+	DefaultProfile = "default"
+	// ProfileEnvVar selects the active profile from the environment
+	ProfileEnvVar = "CLOUD_STORAGE_PROFILE"
+	// APIKeyEnvVar overrides the API key of the active profile
+	APIKeyEnvVar = "CLOUD_STORAGE_API_KEY"
+	// APIURLEnvVar overrides the API URL of the active profile
+	APIURLEnvVar = "CLOUD_STORAGE_API_URL"
+
+	currentProfileKey = "current_profile"
+	profilesKey       = "profiles"
+	legacyAPIKeyKey   = "api_key"
+)
+
+var (
+	// profileNameRegex restricts profile names to characters that are safe as YAML keys
+	profileNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
 )
 
-// GetAPIURL returns the API URL - hardcoded at compile time, cannot be changed at runtime
+// GetAPIURL returns the API URL hardcoded at compile time
+// It is the fallback when no --api-url flag, CLOUD_STORAGE_API_URL or profile entry is set.
 func GetAPIURL() string {
 	if BuildTimeAPIURL != "" {
 		return BuildTimeAPIURL
@@ -59,8 +92,7 @@ func GetAPIURL() string {
 func InitConfig() error {
 	viperInstance = viper.New()
 
-	// Set defaults - API URL is hardcoded at compile time, not from config
-	viperInstance.SetDefault("api_url", GetAPIURL())
+	// Set defaults
 	viperInstance.SetDefault("api_key", "")
 
 	// Set config file name and type
@@ -84,8 +116,8 @@ func InitConfig() error {
 	viperInstance.AutomaticEnv()
 
 	// Bind environment variables
-	// Note: API URL is hardcoded at compile time, env var is ignored
-	viperInstance.BindEnv("api_key", "CLOUD_STORAGE_API_KEY")
+	// Note: the API URL env var is read in resolveAPIURL so that it can be validated
+	viperInstance.BindEnv("api_key", APIKeyEnvVar)
 
 	// Read config file (ignore error if file doesn't exist)
 	if err := viperInstance.ReadInConfig(); err != nil {
@@ -99,6 +131,8 @@ func InitConfig() error {
 }
 
 // LoadConfig loads configuration from file and environment variables
+// The API key comes from the active profile's credential store unless
+// CLOUD_STORAGE_API_KEY is set.
 func LoadConfig() (*Config, error) {
 	if viperInstance == nil {
 		if err := InitConfig(); err != nil {
@@ -106,18 +140,150 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
-	var cfg Config
-	if err := viperInstance.Unmarshal(&cfg); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	profile := ActiveProfile()
+	cfg := Config{
+		Profile:         profile,
+		CredentialStore: profileCredentialStore(profile),
 	}
 
-	// API URL is hardcoded at compile time - always use the build-time value
-	cfg.APIURL = GetAPIURL()
+	if envKey := os.Getenv(APIKeyEnvVar); envKey != "" {
+		cfg.APIKey = envKey
+	} else {
+		apiKey, err := loadAPIKey(profile, cfg.CredentialStore)
+		if err != nil {
+			return nil, err
+		}
+		cfg.APIKey = apiKey
+	}
+
+	apiURL, err := resolveAPIURL(profile)
+	if err != nil {
+		return nil, err
+	}
+	cfg.APIURL = apiURL
 
 	return &cfg, nil
 }
 
+// loadAPIKey reads the API key of a profile from its credential store
+// A missing key is not an error; commands report it when they call the API.
+func loadAPIKey(profile, storeName string) (string, error) {
+	if storeName == StoreFile {
+		apiKey := viperInstance.GetString(profileKey(profile, "api_key"))
+		// Configs written before profiles existed keep the key at the top level
+		if apiKey == "" && profile == DefaultProfile {
+			apiKey = viperInstance.GetString(legacyAPIKeyKey)
+		}
+		return apiKey, nil
+	}
+
+	store, err := GetCredentialStore(storeName)
+	if err != nil {
+		return "", err
+	}
+	apiKey, err := store.Get(profile)
+	if errors.Is(err, ErrCredentialNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key from %s store: %w", storeName, err)
+	}
+	return apiKey, nil
+}
+
+// profileCredentialStore returns the credential store a profile's key is kept in
+func profileCredentialStore(profile string) string {
+	if name := viperInstance.GetString(profileKey(profile, credentialStoreKey)); name != "" {
+		return name
+	}
+	return StoreFile
+}
+
+// resolveAPIURL returns the API URL from the --api-url flag, CLOUD_STORAGE_API_URL,
+// the profile's api_url entry, or the build-time value, in that order
+func resolveAPIURL(profile string) (string, error) {
+	if apiURLOverride != "" {
+		return apiURLOverride, nil
+	}
+
+	if envURL := strings.TrimSpace(os.Getenv(APIURLEnvVar)); envURL != "" {
+		if err := ValidateAPIURL(envURL); err != nil {
+			return "", fmt.Errorf("invalid %s: %w", APIURLEnvVar, err)
+		}
+		return strings.TrimRight(envURL, "/"), nil
+	}
+
+	if profileURL := strings.TrimSpace(viperInstance.GetString(profileKey(profile, "api_url"))); profileURL != "" {
+		if err := ValidateAPIURL(profileURL); err != nil {
+			return "", fmt.Errorf("invalid api_url in profile %s: %w", profile, err)
+		}
+		return strings.TrimRight(profileURL, "/"), nil
+	}
+
+	return GetAPIURL(), nil
+}
+
+// ValidateAPIURL validates an API URL override
+// The URL must be absolute with an http or https scheme and a host.
+func ValidateAPIURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid API URL: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("invalid API URL: %s (scheme must be http or https)", rawURL)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("invalid API URL: %s (missing host)", rawURL)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return fmt.Errorf("invalid API URL: %s (query and fragment are not allowed)", rawURL)
+	}
+	return nil
+}
+
+// SetAPIURLOverride sets the API URL for this process (e.g. from --api-url)
+// An empty value clears the override.
+func SetAPIURLOverride(rawURL string) error {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		apiURLOverride = ""
+		return nil
+	}
+	if err := ValidateAPIURL(rawURL); err != nil {
+		return err
+	}
+	apiURLOverride = strings.TrimRight(rawURL, "/")
+	return nil
+}
+
+// SetProfileAPIURL stores the API URL used by a profile
+// An empty value removes the entry so the profile falls back to the build-time URL.
+func SetProfileAPIURL(profile, rawURL string) error {
+	if err := ValidateProfileName(profile); err != nil {
+		return err
+	}
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL != "" {
+		if err := ValidateAPIURL(rawURL); err != nil {
+			return err
+		}
+		rawURL = strings.TrimRight(rawURL, "/")
+	}
+
+	return updateProfileEntry(profile, func(entry map[string]interface{}) {
+		if rawURL == "" {
+			delete(entry, "api_url")
+		} else {
+			entry["api_url"] = rawURL
+		}
+	})
+}
+
 // SaveConfig saves configuration to file
+// The API key is stored in cfg.Profile, or in the active profile if it is empty,
+// using the cfg.CredentialStore backend (the profile's current backend if empty).
+// Moving a profile to another backend removes the key from the old one.
 func SaveConfig(cfg *Config) error {
 	if viperInstance == nil {
 		if err := InitConfig(); err != nil {
@@ -125,26 +291,323 @@ func SaveConfig(cfg *Config) error {
 		}
 	}
 
-	// Set values in Viper
-	viperInstance.Set("api_url", cfg.APIURL)
-	viperInstance.Set("api_key", cfg.APIKey)
+	profile := cfg.Profile
+	if profile == "" {
+		profile = ActiveProfile()
+	}
+	if err := ValidateProfileName(profile); err != nil {
+		return err
+	}
+
+	previous := profileCredentialStore(profile)
+	storeName := cfg.CredentialStore
+	if storeName == "" {
+		storeName = previous
+	}
+	store, err := GetCredentialStore(storeName)
+	if err != nil {
+		return err
+	}
+
+	// Store the key before recording the backend so a failure leaves the old key usable
+	if storeName != StoreFile {
+		if err := store.Set(profile, cfg.APIKey); err != nil {
+			return err
+		}
+	}
+
+	err = updateProfileEntry(profile, func(entry map[string]interface{}) {
+		if storeName == StoreFile {
+			entry["api_key"] = cfg.APIKey
+			delete(entry, credentialStoreKey)
+		} else {
+			// Never leave a plaintext copy behind
+			delete(entry, "api_key")
+			entry[credentialStoreKey] = storeName
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if previous != storeName && previous != StoreFile {
+		return deleteCredential(profile, previous)
+	}
+	return nil
+}
+
+// deleteCredential removes a profile's key from a non-file credential store
+func deleteCredential(profile, storeName string) error {
+	store, err := GetCredentialStore(storeName)
+	if err != nil {
+		return err
+	}
+	if err := store.Delete(profile); err != nil && !errors.Is(err, ErrCredentialNotFound) {
+		return fmt.Errorf("failed to remove API key from %s store: %w", storeName, err)
+	}
+	return nil
+}
+
+// DeleteAPIKey removes the stored API key of a profile, keeping its other settings
+// Profiles that do not exist are left alone.
+func DeleteAPIKey(profile string) error {
+	if viperInstance == nil {
+		if err := InitConfig(); err != nil {
+			return err
+		}
+	}
+	if err := ValidateProfileName(profile); err != nil {
+		return err
+	}
+
+	settings, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	profiles := profilesMap(settings)
+	entry, ok := profiles[profile].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	if storeName, _ := entry[credentialStoreKey].(string); storeName != "" && storeName != StoreFile {
+		if err := deleteCredential(profile, storeName); err != nil {
+			return err
+		}
+	}
+
+	delete(entry, "api_key")
+	delete(entry, credentialStoreKey)
+	settings[profilesKey] = profiles
+	return writeConfigFile(settings)
+}
+
+// ActiveProfile returns the profile selected by --profile, CLOUD_STORAGE_PROFILE,
+// the current_profile setting, or the default profile, in that order
+func ActiveProfile() string {
+	if profileOverride != "" {
+		return profileOverride
+	}
+	if env := strings.TrimSpace(os.Getenv(ProfileEnvVar)); env != "" {
+		return strings.ToLower(env)
+	}
+	if viperInstance != nil {
+		if current := viperInstance.GetString(currentProfileKey); current != "" {
+			return current
+		}
+	}
+	return DefaultProfile
+}
+
+// SetProfileOverride selects the active profile for this process (e.g. from --profile)
+func SetProfileOverride(name string) {
+	profileOverride = strings.ToLower(strings.TrimSpace(name))
+}
+
+// ValidateProfileName validates a profile name
+func ValidateProfileName(name string) error {
+	if name == "" {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid profile name: %s (use lowercase letters, numbers, '-' and '_')", name)
+	}
+	return nil
+}
+
+// ProfileInfo describes a stored profile
+// APIURL is empty when the profile uses the build-time URL, and APIKey is only
+// filled in for profiles using the file credential store.
+type ProfileInfo struct {
+	Name            string `json:"name"`
+	APIURL          string `json:"apiUrl,omitempty"`
+	APIKey          string `json:"apiKey,omitempty"`
+	CredentialStore string `json:"credentialStore"`
+	Active          bool   `json:"active"`
+}
+
+// ListProfiles returns all stored profiles sorted by name
+func ListProfiles() ([]ProfileInfo, error) {
+	settings, err := readConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	active := ActiveProfile()
+	profiles := profilesMap(settings)
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	infos := make([]ProfileInfo, 0, len(names))
+	for _, name := range names {
+		entry, _ := profiles[name].(map[string]interface{})
+		key, _ := entry["api_key"].(string)
+		apiURL, _ := entry["api_url"].(string)
+		storeName, _ := entry[credentialStoreKey].(string)
+		if storeName == "" {
+			storeName = StoreFile
+		}
+		infos = append(infos, ProfileInfo{
+			Name:            name,
+			APIURL:          apiURL,
+			APIKey:          key,
+			CredentialStore: storeName,
+			Active:          name == active,
+		})
+	}
+	return infos, nil
+}
+
+// UseProfile makes name the profile used when none is selected explicitly
+func UseProfile(name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	settings, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	if _, ok := profilesMap(settings)[name]; !ok {
+		return fmt.Errorf("profile not found: %s", name)
+	}
+
+	settings[currentProfileKey] = name
+	return writeConfigFile(settings)
+}
+
+// DeleteProfile removes a stored profile
+// Deleting the current profile makes the default profile current again.
+func DeleteProfile(name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	settings, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	profiles := profilesMap(settings)
+	entry, ok := profiles[name].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("profile not found: %s", name)
+	}
+
+	if storeName, _ := entry[credentialStoreKey].(string); storeName != "" && storeName != StoreFile {
+		if err := deleteCredential(name, storeName); err != nil {
+			return err
+		}
+	}
+
+	delete(profiles, name)
+	settings[profilesKey] = profiles
+	if current, _ := settings[currentProfileKey].(string); current == name {
+		delete(settings, currentProfileKey)
+	}
+	return writeConfigFile(settings)
+}
+
+// profileKey returns the viper key of a setting within a profile
+func profileKey(profile, key string) string {
+	return profilesKey + "." + profile + "." + key
+}
+
+// profilesMap returns the profiles section of settings, migrating a legacy
+// top-level api_key into the default profile
+func profilesMap(settings map[string]interface{}) map[string]interface{} {
+	profiles, _ := settings[profilesKey].(map[string]interface{})
+	if profiles == nil {
+		profiles = map[string]interface{}{}
+	}
+
+	if legacyKey, ok := settings[legacyAPIKeyKey].(string); ok {
+		delete(settings, legacyAPIKeyKey)
+		if _, exists := profiles[DefaultProfile]; !exists && legacyKey != "" {
+			profiles[DefaultProfile] = map[string]interface{}{"api_key": legacyKey}
+		}
+	}
+	// Older versions wrote the build-time API URL at the top level, where it was never read
+	delete(settings, "api_url")
+
+	return profiles
+}
+
+// updateProfileEntry applies update to a profile's entry in the config file,
+// creating the entry if needed
+func updateProfileEntry(profile string, update func(entry map[string]interface{})) error {
+	settings, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+
+	profiles := profilesMap(settings)
+	entry, _ := profiles[profile].(map[string]interface{})
+	if entry == nil {
+		entry = map[string]interface{}{}
+	}
+	update(entry)
+	profiles[profile] = entry
+	settings[profilesKey] = profiles
+
+	return writeConfigFile(settings)
+}
+
+// readConfigFile reads the settings stored in the config file, without
+// environment variables or defaults mixed in
+func readConfigFile() (map[string]interface{}, error) {
+	path := GetConfigPath()
+	fileViper := viper.New()
+	fileViper.SetConfigFile(path)
+	fileViper.SetConfigType("yaml")
+	if err := fileViper.ReadInConfig(); err != nil {
+		// No config file yet is okay, start from empty settings
+		if os.IsNotExist(err) {
+			return map[string]interface{}{}, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return fileViper.AllSettings(), nil
+}
+
+// writeConfigFile replaces the config file with settings and reloads the configuration
+func writeConfigFile(settings map[string]interface{}) error {
+	path := GetConfigPath()
 
 	// Ensure config directory exists
-	configDir := filepath.Dir(configPath)
+	configDir := filepath.Dir(path)
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
+	fileViper := viper.New()
+	fileViper.SetConfigType("yaml")
+	if err := fileViper.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to prepare config: %w", err)
+	}
+
 	// Write config file
-	if err := viperInstance.WriteConfigAs(configPath); err != nil {
+	if err := fileViper.WriteConfigAs(path); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
 	// Set secure file permissions (0600: owner read/write only)
-	if err := os.Chmod(configPath, 0600); err != nil {
+	if err := os.Chmod(path, 0600); err != nil {
 		return fmt.Errorf("failed to set config file permissions: %w", err)
 	}
 
+	// Reload so later reads see the new values
+	if viperInstance != nil {
+		viperInstance.SetConfigFile(path)
+		if err := viperInstance.ReadInConfig(); err != nil {
+			return fmt.Errorf("failed to reload config file: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -180,7 +643,7 @@ func SetValue(key, value string) error {
 	}
 
 	// Update the specified key
-	// Note: api-url cannot be set via SetValue - it should only come from env var or flag
+	// Note: api-url cannot be set via SetValue - it is stored per profile by 'auth login --api-url'
 	switch key {
 	case "api-key", "api_key":
 		cfg.APIKey = value
@@ -201,10 +664,11 @@ func GetValue(key string) (string, error) {
 
 	switch key {
 	case "api-url", "api_url":
-		// API URL is hardcoded at compile time
-		return GetAPIURL(), nil
+		return cfg.APIURL, nil
 	case "api-key", "api_key":
 		return cfg.APIKey, nil
+	case "profile":
+		return cfg.Profile, nil
 	default:
 		return "", fmt.Errorf("unknown config key: %s", key)
 	}
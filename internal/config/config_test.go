@@ -43,10 +43,18 @@ func setupTestConfig(t *testing.T) (string, func()) {
 	if originalHome == "" {
 		originalHome = os.Getenv("USERPROFILE") // Windows
 	}
+	os.Setenv("HOME", tmpDir)
+	os.Setenv("USERPROFILE", tmpDir)
+	originalOverride := profileOverride
+	profileOverride = ""
+	originalURLOverride := apiURLOverride
+	apiURLOverride = ""
 
 	cleanup := func() {
 		viperInstance = originalViper
 		configPath = originalPath
+		profileOverride = originalOverride
+		apiURLOverride = originalURLOverride
 		os.RemoveAll(tmpDir)
 		if originalHome != "" {
 			os.Setenv("HOME", originalHome)
@@ -313,9 +321,8 @@ func TestConfig_EnvironmentVariables(t *testing.T) {
 	_, cleanup := setupTestConfig(t)
 	defer cleanup()
 
-	// Set environment variable (should be ignored - API URL is compile-time only)
-	os.Setenv("CLOUD_STORAGE_API_URL", "http://env.example.com")
-	defer os.Unsetenv("CLOUD_STORAGE_API_URL")
+	// Environment variable overrides the compile-time URL
+	t.Setenv(APIURLEnvVar, "http://env.example.com/")
 
 	// Reset viper
 	viperInstance = nil
@@ -325,10 +332,259 @@ func TestConfig_EnvironmentVariables(t *testing.T) {
 		t.Fatalf("LoadConfig() error = %v", err)
 	}
 
-	// API URL should be compile-time value, not from env var
-	expectedURL := GetAPIURL()
-	if cfg.APIURL != expectedURL {
-		t.Errorf("Expected APIURL %q (compile-time, env var ignored), got %q", expectedURL, cfg.APIURL)
+	if cfg.APIURL != "http://env.example.com" {
+		t.Errorf("Expected APIURL %q from env var, got %q", "http://env.example.com", cfg.APIURL)
+	}
+}
+
+func TestConfig_InvalidAPIURLEnv(t *testing.T) {
+	_, cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	t.Setenv(APIURLEnvVar, "ftp://env.example.com")
+	viperInstance = nil
+
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected error for invalid CLOUD_STORAGE_API_URL, got nil")
+	}
+}
+
+func TestAPIURL_Precedence(t *testing.T) {
+	_, cleanup := setupTestConfig(t)
+	defer cleanup()
+	t.Setenv(APIURLEnvVar, "")
+	t.Setenv(ProfileEnvVar, "")
+
+	if err := SetProfileAPIURL(DefaultProfile, "https://profile.example.com"); err != nil {
+		t.Fatalf("SetProfileAPIURL() error = %v", err)
+	}
+	if got, _ := GetValue("api-url"); got != "https://profile.example.com" {
+		t.Errorf("Expected profile URL, got %q", got)
+	}
+
+	// Saving the key must keep the profile URL
+	if err := SetValue("api-key", "profile-key"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	if got, _ := GetValue("api-url"); got != "https://profile.example.com" {
+		t.Errorf("Expected profile URL after saving key, got %q", got)
+	}
+
+	t.Setenv(APIURLEnvVar, "https://env.example.com")
+	if got, _ := GetValue("api-url"); got != "https://env.example.com" {
+		t.Errorf("Expected env URL to override profile, got %q", got)
+	}
+
+	if err := SetAPIURLOverride("https://flag.example.com"); err != nil {
+		t.Fatalf("SetAPIURLOverride() error = %v", err)
+	}
+	if got, _ := GetValue("api-url"); got != "https://flag.example.com" {
+		t.Errorf("Expected flag URL to override env, got %q", got)
+	}
+
+	// Clearing the overrides falls back to the profile, then the build-time URL
+	if err := SetAPIURLOverride(""); err != nil {
+		t.Fatalf("SetAPIURLOverride() error = %v", err)
+	}
+	t.Setenv(APIURLEnvVar, "")
+	if err := SetProfileAPIURL(DefaultProfile, ""); err != nil {
+		t.Fatalf("SetProfileAPIURL() error = %v", err)
+	}
+	if got, _ := GetValue("api-url"); got != GetAPIURL() {
+		t.Errorf("Expected build-time URL %q, got %q", GetAPIURL(), got)
+	}
+}
+
+func TestValidateAPIURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"http://localhost:8080", false},
+		{"https://api.example.com", false},
+		{"https://api.example.com/base/", false},
+		{"", true},
+		{"api.example.com", true},
+		{"ftp://api.example.com", true},
+		{"http://", true},
+		{"https://api.example.com?x=1", true},
+		{"http://bad host", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := ValidateAPIURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAPIURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProfiles_SeparateKeys(t *testing.T) {
+	_, cleanup := setupTestConfig(t)
+	defer cleanup()
+	t.Setenv(APIKeyEnvVar, "")
+	t.Setenv(ProfileEnvVar, "")
+
+	if err := SaveConfig(&Config{APIKey: "default-key"}); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	if err := SaveConfig(&Config{APIKey: "work-key", Profile: "work"}); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Profile != DefaultProfile || cfg.APIKey != "default-key" {
+		t.Errorf("Expected default profile with default-key, got %q with %q", cfg.Profile, cfg.APIKey)
+	}
+
+	SetProfileOverride("Work")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Profile != "work" || cfg.APIKey != "work-key" {
+		t.Errorf("Expected work profile with work-key, got %q with %q", cfg.Profile, cfg.APIKey)
+	}
+}
+
+func TestProfiles_EnvironmentSelection(t *testing.T) {
+	_, cleanup := setupTestConfig(t)
+	defer cleanup()
+	t.Setenv(APIKeyEnvVar, "")
+
+	if err := SaveConfig(&Config{APIKey: "ci-key", Profile: "ci"}); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+
+	t.Setenv(ProfileEnvVar, "ci")
+	if got := ActiveProfile(); got != "ci" {
+		t.Errorf("ActiveProfile() = %q, want %q", got, "ci")
+	}
+
+	// The --profile flag takes precedence over the environment
+	SetProfileOverride("other")
+	if got := ActiveProfile(); got != "other" {
+		t.Errorf("ActiveProfile() = %q, want %q", got, "other")
+	}
+}
+
+func TestProfiles_UseAndDelete(t *testing.T) {
+	_, cleanup := setupTestConfig(t)
+	defer cleanup()
+	t.Setenv(APIKeyEnvVar, "")
+	t.Setenv(ProfileEnvVar, "")
+
+	if err := SaveConfig(&Config{APIKey: "default-key"}); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	if err := SaveConfig(&Config{APIKey: "work-key", Profile: "work"}); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+
+	if err := UseProfile("missing"); err == nil {
+		t.Error("Expected error when using a missing profile, got nil")
+	}
+	if err := UseProfile("work"); err != nil {
+		t.Fatalf("UseProfile() error = %v", err)
+	}
+
+	// Reload from disk to verify the selection persisted
+	viperInstance = nil
+	if got := ActiveProfile(); got != DefaultProfile {
+		t.Errorf("ActiveProfile() before init = %q, want %q", got, DefaultProfile)
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.Profile != "work" || cfg.APIKey != "work-key" {
+		t.Errorf("Expected work profile with work-key, got %q with %q", cfg.Profile, cfg.APIKey)
+	}
+
+	profiles, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles() error = %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].Name != "default" || profiles[1].Name != "work" || !profiles[1].Active {
+		t.Errorf("Unexpected profiles: %+v", profiles)
+	}
+
+	if err := DeleteProfile("work"); err != nil {
+		t.Fatalf("DeleteProfile() error = %v", err)
+	}
+	if got := ActiveProfile(); got != DefaultProfile {
+		t.Errorf("ActiveProfile() after delete = %q, want %q", got, DefaultProfile)
+	}
+	profiles, err = ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles() error = %v", err)
+	}
+	if len(profiles) != 1 || profiles[0].Name != DefaultProfile {
+		t.Errorf("Unexpected profiles after delete: %+v", profiles)
+	}
+	if err := DeleteProfile("work"); err == nil {
+		t.Error("Expected error when deleting a missing profile, got nil")
+	}
+}
+
+func TestProfiles_LegacyAPIKey(t *testing.T) {
+	_, cleanup := setupTestConfig(t)
+	defer cleanup()
+	t.Setenv(APIKeyEnvVar, "")
+	t.Setenv(ProfileEnvVar, "")
+
+	legacy := []byte("api_url: http://localhost:8080\napi_key: legacy-key\n")
+	if err := os.WriteFile(configPath, legacy, 0600); err != nil {
+		t.Fatalf("Failed to write legacy config: %v", err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.APIKey != "legacy-key" {
+		t.Errorf("Expected legacy key to load into the default profile, got %q", cfg.APIKey)
+	}
+
+	// Saving another profile migrates the legacy key into the default profile
+	if err := SaveConfig(&Config{APIKey: "work-key", Profile: "work"}); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	profiles, err := ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles() error = %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].APIKey != "legacy-key" {
+		t.Errorf("Expected migrated default profile, got %+v", profiles)
+	}
+}
+
+func TestValidateProfileName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"default", false},
+		{"work-2", false},
+		{"team_a", false},
+		{"", true},
+		{"-leading", true},
+		{"has space", true},
+		{"a.b", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProfileName(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateProfileName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
 	}
 }
 
@@ -0,0 +1,401 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/kdf"
+	"github.com/zalando/go-keyring"
+)
+
+// Credential store backend names
+const (
+	// StoreFile keeps the API key in plaintext in config.yaml
+	StoreFile = "file"
+	// StoreEncrypted keeps API keys in credentials.enc, encrypted with a passphrase
+	StoreEncrypted = "encrypted"
+	// StoreKeyring keeps API keys in the OS keyring (Secret Service, Keychain, Credential Manager)
+	StoreKeyring = "keyring"
+
+	// PassphraseEnvVar supplies the passphrase of the encrypted credential file
+	PassphraseEnvVar = "CLOUD_STORAGE_CREDENTIALS_PASSPHRASE"
+
+	credentialStoreKey  = "credential_store"
+	credentialsFileName = "credentials.enc"
+	keyringService      = "cloud-storage-cli"
+
+	encryptedFileVersion = 1
+)
+
+// ErrCredentialNotFound is returned when a store holds no key for a profile
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// CredentialStore stores API keys per profile
+type CredentialStore interface {
+	// Get returns the API key of a profile, or ErrCredentialNotFound
+	Get(profile string) (string, error)
+	// Set stores the API key of a profile
+	Set(profile, apiKey string) error
+	// Delete removes the API key of a profile, or returns ErrCredentialNotFound
+	Delete(profile string) error
+}
+
+// PassphraseFunc returns the passphrase for the encrypted credential file
+// confirm is true when the file is being created, so the passphrase should be entered twice.
+type PassphraseFunc func(confirm bool) (string, error)
+
+var (
+	credentialStoresMu sync.RWMutex
+	credentialStores   = map[string]CredentialStore{}
+
+	// PassphraseProvider supplies the passphrase of the encrypted credential file
+	// The CLI replaces it with a terminal prompt; the default reads CLOUD_STORAGE_CREDENTIALS_PASSPHRASE.
+	PassphraseProvider PassphraseFunc = passphraseFromEnv
+)
+
+func init() {
+	RegisterCredentialStore(StoreFile, fileStore{})
+	RegisterCredentialStore(StoreEncrypted, NewEncryptedFileStore("", nil))
+	RegisterCredentialStore(StoreKeyring, NewKeyringStore(keyringService))
+}
+
+// RegisterCredentialStore makes a credential store selectable by name
+// Registering an existing name replaces the store.
+func RegisterCredentialStore(name string, store CredentialStore) {
+	credentialStoresMu.Lock()
+	defer credentialStoresMu.Unlock()
+	credentialStores[name] = store
+}
+
+// GetCredentialStore returns the credential store registered under name
+func GetCredentialStore(name string) (CredentialStore, error) {
+	credentialStoresMu.RLock()
+	defer credentialStoresMu.RUnlock()
+	store, ok := credentialStores[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown credential store: %s (available: %v)", name, credentialStoreNamesLocked())
+	}
+	return store, nil
+}
+
+// CredentialStoreNames returns the names of all registered credential stores
+func CredentialStoreNames() []string {
+	credentialStoresMu.RLock()
+	defer credentialStoresMu.RUnlock()
+	return credentialStoreNamesLocked()
+}
+
+func credentialStoreNamesLocked() []string {
+	names := make([]string, 0, len(credentialStores))
+	for name := range credentialStores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// passphraseFromEnv reads the passphrase from CLOUD_STORAGE_CREDENTIALS_PASSPHRASE
+func passphraseFromEnv(confirm bool) (string, error) {
+	passphrase := os.Getenv(PassphraseEnvVar)
+	if passphrase == "" {
+		return "", fmt.Errorf("no passphrase for the encrypted credential store (set %s)", PassphraseEnvVar)
+	}
+	return passphrase, nil
+}
+
+// fileStore keeps API keys in plaintext in the profile entries of config.yaml
+type fileStore struct{}
+
+func (fileStore) Get(profile string) (string, error) {
+	settings, err := readConfigFile()
+	if err != nil {
+		return "", err
+	}
+	entry, _ := profilesMap(settings)[profile].(map[string]interface{})
+	key, _ := entry["api_key"].(string)
+	if key == "" {
+		return "", ErrCredentialNotFound
+	}
+	return key, nil
+}
+
+func (fileStore) Set(profile, apiKey string) error {
+	return updateProfileEntry(profile, func(entry map[string]interface{}) {
+		entry["api_key"] = apiKey
+	})
+}
+
+func (fileStore) Delete(profile string) error {
+	settings, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	profiles := profilesMap(settings)
+	entry, _ := profiles[profile].(map[string]interface{})
+	if _, ok := entry["api_key"]; !ok {
+		return ErrCredentialNotFound
+	}
+	delete(entry, "api_key")
+	settings[profilesKey] = profiles
+	return writeConfigFile(settings)
+}
+
+// keyringStore keeps API keys in the OS keyring, one entry per profile
+type keyringStore struct {
+	service string
+}
+
+// NewKeyringStore creates a credential store backed by the OS keyring
+func NewKeyringStore(service string) CredentialStore {
+	return keyringStore{service: service}
+}
+
+func (s keyringStore) Get(profile string) (string, error) {
+	key, err := keyring.Get(s.service, profile)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrCredentialNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read from keyring: %w", err)
+	}
+	return key, nil
+}
+
+func (s keyringStore) Set(profile, apiKey string) error {
+	if err := keyring.Set(s.service, profile, apiKey); err != nil {
+		return fmt.Errorf("failed to write to keyring: %w", err)
+	}
+	return nil
+}
+
+func (s keyringStore) Delete(profile string) error {
+	err := keyring.Delete(s.service, profile)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrCredentialNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete from keyring: %w", err)
+	}
+	return nil
+}
+
+// encryptedFileStore keeps all API keys in one AES-256-GCM encrypted file
+// The key is derived from a passphrase with PBKDF2-SHA256 and a random salt.
+type encryptedFileStore struct {
+	mu         sync.Mutex
+	path       string
+	passphrase PassphraseFunc
+	iterations int
+
+	// cached is the passphrase that last decrypted the file, so it is asked for once per process
+	cached string
+}
+
+// encryptedFile is the on-disk format of the encrypted credential file
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// NewEncryptedFileStore creates a credential store backed by an encrypted file
+// An empty path uses credentials.enc next to the config file; a nil passphrase
+// function uses PassphraseProvider.
+func NewEncryptedFileStore(path string, passphrase PassphraseFunc) CredentialStore {
+	return &encryptedFileStore{path: path, passphrase: passphrase, iterations: kdf.Iterations}
+}
+
+func (s *encryptedFileStore) Get(profile string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	key, ok := keys[profile]
+	if !ok {
+		return "", ErrCredentialNotFound
+	}
+	return key, nil
+}
+
+func (s *encryptedFileStore) Set(profile, apiKey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys, err := s.load()
+	if err != nil {
+		return err
+	}
+	keys[profile] = apiKey
+	return s.save(keys)
+}
+
+func (s *encryptedFileStore) Delete(profile string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := keys[profile]; !ok {
+		return ErrCredentialNotFound
+	}
+	delete(keys, profile)
+	return s.save(keys)
+}
+
+// filePath returns the path of the encrypted credential file
+func (s *encryptedFileStore) filePath() string {
+	if s.path != "" {
+		return s.path
+	}
+	return filepath.Join(filepath.Dir(GetConfigPath()), credentialsFileName)
+}
+
+// getPassphrase returns the cached passphrase or asks for it
+func (s *encryptedFileStore) getPassphrase(confirm bool) (string, error) {
+	if s.cached != "" {
+		return s.cached, nil
+	}
+	provider := s.passphrase
+	if provider == nil {
+		provider = PassphraseProvider
+	}
+	passphrase, err := provider(confirm)
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase cannot be empty")
+	}
+	return passphrase, nil
+}
+
+// load decrypts the credential file; a missing file holds no keys
+func (s *encryptedFileStore) load() (map[string]string, error) {
+	data, err := os.ReadFile(s.filePath())
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credential file: %w", err)
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse credential file: %w", err)
+	}
+	if file.Version != encryptedFileVersion {
+		return nil, fmt.Errorf("unsupported credential file version: %d", file.Version)
+	}
+
+	passphrase, err := s.getPassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newCredentialCipher(passphrase, file.Salt, file.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, []byte(credentialsFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credential file: incorrect passphrase or corrupted file")
+	}
+	s.cached = passphrase
+
+	keys := map[string]string{}
+	if err := json.Unmarshal(plaintext, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse decrypted credentials: %w", err)
+	}
+	return keys, nil
+}
+
+// save encrypts keys with a fresh salt and nonce and replaces the credential file
+func (s *encryptedFileStore) save(keys map[string]string) error {
+	path := s.filePath()
+	_, statErr := os.Stat(path)
+	passphrase, err := s.getPassphrase(os.IsNotExist(statErr))
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(keys)
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+
+	salt, err := kdf.NewSalt()
+	if err != nil {
+		return err
+	}
+	file := encryptedFile{
+		Version:    encryptedFileVersion,
+		Iterations: s.iterations,
+		Salt:       salt,
+	}
+	gcm, err := newCredentialCipher(passphrase, file.Salt, file.Iterations)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	file.Ciphertext = gcm.Seal(nil, file.Nonce, plaintext, []byte(credentialsFileName))
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode credential file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	// Write to a temporary file first so a failed write cannot lose existing keys
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write credential file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write credential file: %w", err)
+	}
+
+	s.cached = passphrase
+	return nil
+}
+
+// newCredentialCipher derives the file key from the passphrase and returns an AES-256-GCM cipher
+func newCredentialCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	if !kdf.ValidIterations(iterations) {
+		return nil, fmt.Errorf("invalid credential file: bad key derivation parameters")
+	}
+	return kdf.NewCipher(passphrase, salt, iterations)
+}
@@ -0,0 +1,276 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+// storeMemory names the in-memory credential store registered by tests
+const storeMemory = "memory"
+
+// memoryStore keeps API keys in memory
+type memoryStore struct {
+	mu   sync.Mutex
+	keys map[string]string
+}
+
+// newMemoryCredentialStore creates an in-memory credential store
+func newMemoryCredentialStore() CredentialStore {
+	return &memoryStore{keys: map[string]string{}}
+}
+
+func (s *memoryStore) Get(profile string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.keys[profile]
+	if !ok {
+		return "", ErrCredentialNotFound
+	}
+	return key, nil
+}
+
+func (s *memoryStore) Set(profile, apiKey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[profile] = apiKey
+	return nil
+}
+
+func (s *memoryStore) Delete(profile string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[profile]; !ok {
+		return ErrCredentialNotFound
+	}
+	delete(s.keys, profile)
+	return nil
+}
+
+// registerMemoryStore registers a fresh in-memory store for the duration of a test
+func registerMemoryStore(t *testing.T) CredentialStore {
+	store := newMemoryCredentialStore()
+	RegisterCredentialStore(storeMemory, store)
+	t.Cleanup(func() {
+		credentialStoresMu.Lock()
+		delete(credentialStores, storeMemory)
+		credentialStoresMu.Unlock()
+	})
+	return store
+}
+
+// testCredentialStore exercises the CredentialStore contract
+func testCredentialStore(t *testing.T, store CredentialStore) {
+	if _, err := store.Get("work"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("Get() on empty store error = %v, want ErrCredentialNotFound", err)
+	}
+	if err := store.Set("work", "work-key"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := store.Set("ci", "ci-key"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got, err := store.Get("work"); err != nil || got != "work-key" {
+		t.Errorf("Get() = %q, %v, want %q", got, err, "work-key")
+	}
+	if err := store.Delete("work"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get("work"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want ErrCredentialNotFound", err)
+	}
+	if err := store.Delete("work"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Delete() of missing key error = %v, want ErrCredentialNotFound", err)
+	}
+	if got, err := store.Get("ci"); err != nil || got != "ci-key" {
+		t.Errorf("Get() = %q, %v, want %q", got, err, "ci-key")
+	}
+}
+
+func TestMemoryCredentialStore(t *testing.T) {
+	testCredentialStore(t, newMemoryCredentialStore())
+}
+
+func TestKeyringStore(t *testing.T) {
+	keyring.MockInit()
+	testCredentialStore(t, NewKeyringStore("cloud-storage-cli-test"))
+}
+
+func TestEncryptedFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), credentialsFileName)
+	passphrase := func(confirm bool) (string, error) { return "correct horse", nil }
+
+	store := &encryptedFileStore{path: path, passphrase: passphrase, iterations: 1000}
+	testCredentialStore(t, store)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read credential file: %v", err)
+	}
+	if strings.Contains(string(data), "ci-key") {
+		t.Error("Credential file contains the plaintext API key")
+	}
+	if info, err := os.Stat(path); err == nil && info.Mode().Perm()&0077 != 0 {
+		t.Errorf("Credential file permissions = %v, want 0600", info.Mode().Perm())
+	}
+
+	// A fresh store with the same passphrase can read the file
+	reopened := &encryptedFileStore{path: path, passphrase: passphrase, iterations: 1000}
+	if got, err := reopened.Get("ci"); err != nil || got != "ci-key" {
+		t.Errorf("Get() = %q, %v, want %q", got, err, "ci-key")
+	}
+
+	wrong := &encryptedFileStore{
+		path:       path,
+		passphrase: func(confirm bool) (string, error) { return "wrong", nil },
+		iterations: 1000,
+	}
+	if _, err := wrong.Get("ci"); err == nil {
+		t.Error("Expected error for wrong passphrase, got nil")
+	}
+
+	// A tampered work factor is rejected instead of hanging the CLI
+	var file encryptedFile
+	json.Unmarshal(data, &file)
+	file.Iterations = 1 << 30
+	tampered, _ := json.Marshal(file)
+	if err := os.WriteFile(path, tampered, 0600); err != nil {
+		t.Fatalf("Failed to write credential file: %v", err)
+	}
+	if _, err := reopened.Get("ci"); err == nil || !strings.Contains(err.Error(), "key derivation") {
+		t.Errorf("Expected a key derivation error for a tampered file, got %v", err)
+	}
+}
+
+func TestSaveConfig_CredentialStore(t *testing.T) {
+	_, cleanup := setupTestConfig(t)
+	defer cleanup()
+	t.Setenv(APIKeyEnvVar, "")
+	t.Setenv(ProfileEnvVar, "")
+	store := registerMemoryStore(t)
+
+	// Start with a plaintext key, then move it to the memory store
+	if err := SaveConfig(&Config{APIKey: "plain-key"}); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	if err := SaveConfig(&Config{APIKey: "secret-key", CredentialStore: storeMemory}); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	if strings.Contains(string(data), "plain-key") || strings.Contains(string(data), "secret-key") {
+		t.Errorf("Config file still contains an API key:\n%s", data)
+	}
+
+	viperInstance = nil
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.APIKey != "secret-key" || cfg.CredentialStore != storeMemory {
+		t.Errorf("Expected secret-key from memory store, got %q from %q", cfg.APIKey, cfg.CredentialStore)
+	}
+
+	// Saving without a backend keeps the profile's current backend
+	if err := SetValue("api-key", "rotated-key"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	if got, _ := store.Get(DefaultProfile); got != "rotated-key" {
+		t.Errorf("Expected rotated-key in memory store, got %q", got)
+	}
+
+	// Moving back to the file store removes the key from the memory store
+	if err := SaveConfig(&Config{APIKey: "file-key", CredentialStore: StoreFile}); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	if _, err := store.Get(DefaultProfile); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Expected key removed from memory store, got error %v", err)
+	}
+	if got, _ := GetValue("api-key"); got != "file-key" {
+		t.Errorf("Expected file-key, got %q", got)
+	}
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	_, cleanup := setupTestConfig(t)
+	defer cleanup()
+	t.Setenv(APIKeyEnvVar, "")
+	t.Setenv(ProfileEnvVar, "")
+	store := registerMemoryStore(t)
+
+	if err := SaveConfig(&Config{APIKey: "secret-key", Profile: "work", CredentialStore: storeMemory}); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	if err := SetProfileAPIURL("work", "https://work.example.com"); err != nil {
+		t.Fatalf("SetProfileAPIURL() error = %v", err)
+	}
+
+	if err := DeleteAPIKey("work"); err != nil {
+		t.Fatalf("DeleteAPIKey() error = %v", err)
+	}
+	if _, err := store.Get("work"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Expected key removed from memory store, got error %v", err)
+	}
+
+	SetProfileOverride("work")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.APIKey != "" || cfg.APIURL != "https://work.example.com" {
+		t.Errorf("Expected no key and the profile URL, got %q and %q", cfg.APIKey, cfg.APIURL)
+	}
+}
+
+func TestDeleteProfile_RemovesStoredKey(t *testing.T) {
+	_, cleanup := setupTestConfig(t)
+	defer cleanup()
+	t.Setenv(APIKeyEnvVar, "")
+	store := registerMemoryStore(t)
+
+	if err := SaveConfig(&Config{APIKey: "secret-key", Profile: "work", CredentialStore: storeMemory}); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	if err := DeleteProfile("work"); err != nil {
+		t.Fatalf("DeleteProfile() error = %v", err)
+	}
+	if _, err := store.Get("work"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Expected key removed from memory store, got error %v", err)
+	}
+}
+
+func TestGetCredentialStore_Unknown(t *testing.T) {
+	_, cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	if _, err := GetCredentialStore("nope"); err == nil {
+		t.Error("Expected error for unknown credential store, got nil")
+	}
+	if err := SaveConfig(&Config{APIKey: "key", CredentialStore: "nope"}); err == nil {
+		t.Error("Expected SaveConfig() error for unknown credential store, got nil")
+	}
+}
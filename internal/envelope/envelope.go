@@ -0,0 +1,354 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envelope implements client-side envelope encryption of file contents
+//
+// Every file is encrypted with its own random data key using AES-256-GCM in
+// chunks of ChunkSize bytes, so files of any size are streamed without being
+// held in memory. The data key is wrapped (AES-256-GCM) with a key-encryption
+// key derived from a passphrase (PBKDF2-SHA256) or read from a key file, and
+// stored in a fixed-size header:
+//
+//	magic "CSCENV" | version | kdf | iterations (uint32) | salt (16) |
+//	chunk size (uint32) | nonce prefix (7) | wrap nonce (12) | wrapped data key (48)
+//
+// Each chunk is sealed with the nonce prefix, a big-endian chunk counter and a
+// final-chunk flag, so reordered, dropped or truncated chunks fail to decrypt.
+// The last chunk is always shorter than ChunkSize and may be empty.
+package envelope
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/kdf"
+)
+
+const (
+	// Suffix is appended to the names of encrypted files
+	Suffix = ".enc"
+
+	// ChunkSize is the amount of plaintext sealed per chunk
+	ChunkSize = 64 * 1024
+
+	// MagicSize is the number of leading bytes that identify an envelope
+	MagicSize = len(magic)
+
+	// HeaderSize is the length of the envelope header
+	HeaderSize = MagicSize + 1 + 1 + 4 + saltSize + 4 + noncePrefixSize + nonceSize + keySize + tagSize
+
+	magic   = "CSCENV"
+	version = 1
+
+	kdfPBKDF2 = 1
+	kdfRawKey = 2
+
+	keySize         = kdf.KeySize
+	saltSize        = kdf.SaltSize
+	nonceSize       = 12
+	noncePrefixSize = 7
+	tagSize         = 16
+	maxChunkSize    = 16 << 20
+)
+
+// ErrWrongKey is returned when the wrapped data key cannot be opened
+var ErrWrongKey = errors.New("wrong passphrase or key")
+
+// ErrCorrupted is returned when a chunk fails authentication or the envelope is truncated
+var ErrCorrupted = errors.New("encrypted data is corrupted or truncated")
+
+// IsEnvelope reports whether data starts with an envelope header
+func IsEnvelope(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(magic))
+}
+
+// IsEncryptedName reports whether a stored filename marks an encrypted file
+func IsEncryptedName(name string) bool {
+	return strings.HasSuffix(name, Suffix) && len(name) > len(Suffix)
+}
+
+// EncryptedSize returns the size of the envelope of a plaintext of n bytes
+func EncryptedSize(n int64) int64 {
+	return int64(HeaderSize) + n + (n/ChunkSize+1)*tagSize
+}
+
+// PlaintextSize returns the plaintext size of an envelope of n bytes written
+// with the default chunk size, or -1 if no envelope has that size
+func PlaintextSize(n int64) int64 {
+	body := n - int64(HeaderSize)
+	if body < tagSize {
+		return -1
+	}
+	full, rest := body/(ChunkSize+tagSize), body%(ChunkSize+tagSize)
+	if rest < tagSize {
+		return -1
+	}
+	return full*ChunkSize + rest - tagSize
+}
+
+// header is the decoded envelope header
+type header struct {
+	kdf         byte
+	iterations  uint32
+	salt        []byte
+	chunkSize   uint32
+	noncePrefix []byte
+	wrapNonce   []byte
+	wrappedKey  []byte
+}
+
+// marshalPrefix encodes every header field that is authenticated by the key wrap
+func (h *header) marshalPrefix() []byte {
+	buf := make([]byte, 0, HeaderSize)
+	buf = append(buf, magic...)
+	buf = append(buf, version, h.kdf)
+	buf = binary.BigEndian.AppendUint32(buf, h.iterations)
+	buf = append(buf, h.salt...)
+	buf = binary.BigEndian.AppendUint32(buf, h.chunkSize)
+	buf = append(buf, h.noncePrefix...)
+	buf = append(buf, h.wrapNonce...)
+	return buf
+}
+
+// parseHeader decodes an envelope header
+func parseHeader(data []byte) (*header, error) {
+	if !IsEnvelope(data) {
+		return nil, fmt.Errorf("not an encrypted file")
+	}
+	if data[MagicSize] != version {
+		return nil, fmt.Errorf("unsupported encryption format version: %d", data[MagicSize])
+	}
+	rest := data[MagicSize+1:]
+	take := func(n int) []byte {
+		b := rest[:n]
+		rest = rest[n:]
+		return b
+	}
+	h := &header{kdf: take(1)[0]}
+	h.iterations = binary.BigEndian.Uint32(take(4))
+	h.salt = take(saltSize)
+	h.chunkSize = binary.BigEndian.Uint32(take(4))
+	h.noncePrefix = take(noncePrefixSize)
+	h.wrapNonce = take(nonceSize)
+	h.wrappedKey = take(keySize + tagSize)
+	if h.chunkSize == 0 || h.chunkSize > maxChunkSize {
+		return nil, fmt.Errorf("invalid chunk size in encrypted file: %d", h.chunkSize)
+	}
+	return h, nil
+}
+
+// chunkNonce returns the nonce of chunk number counter
+func chunkNonce(prefix []byte, counter uint32, final bool) []byte {
+	nonce := make([]byte, 0, nonceSize)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+	if final {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+// encryptReader produces the envelope of a plaintext stream as it is read
+type encryptReader struct {
+	src     io.Reader
+	key     *Key
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	plain   []byte
+	pending []byte
+	done    bool
+}
+
+// NewEncryptReader returns a reader of the envelope of src, encrypted for key
+// The reader implements io.Seeker for rewinding to the start when src does,
+// which starts a fresh envelope with a new data key.
+func NewEncryptReader(src io.Reader, key *Key) (io.ReadSeeker, error) {
+	r := &encryptReader{src: src, key: key, plain: make([]byte, ChunkSize)}
+	if err := r.start(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// start generates a data key and queues the header
+func (r *encryptReader) start() error {
+	h, dataKey, err := r.key.newHeader()
+	if err != nil {
+		return err
+	}
+	aead, err := kdf.NewGCM(dataKey)
+	if err != nil {
+		return err
+	}
+	r.aead = aead
+	r.prefix = h.noncePrefix
+	r.counter = 0
+	r.done = false
+	r.pending = append(h.marshalPrefix(), h.wrappedKey...)
+	return nil
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.sealNext(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// sealNext reads the next chunk of plaintext and queues its ciphertext
+func (r *encryptReader) sealNext() error {
+	n, err := io.ReadFull(r.src, r.plain)
+	final := err == io.EOF || err == io.ErrUnexpectedEOF
+	if err != nil && !final {
+		return err
+	}
+	if r.counter == math.MaxUint32 {
+		return fmt.Errorf("file too large to encrypt")
+	}
+	r.pending = r.aead.Seal(r.pending[:0], chunkNonce(r.prefix, r.counter, final), r.plain[:n], nil)
+	r.counter++
+	r.done = final
+	return nil
+}
+
+// Seek rewinds to the start of a new envelope; only Seek(0, io.SeekStart) is supported
+func (r *encryptReader) Seek(offset int64, whence int) (int64, error) {
+	if offset != 0 || whence != io.SeekStart {
+		return 0, fmt.Errorf("encrypted stream can only be rewound to the start")
+	}
+	seeker, ok := r.src.(io.Seeker)
+	if !ok {
+		return 0, fmt.Errorf("encrypted stream cannot be rewound")
+	}
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return 0, r.start()
+}
+
+// decryptReader produces the plaintext of an envelope as it is read
+type decryptReader struct {
+	src     io.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	chunk   []byte
+	pending []byte
+	done    bool
+}
+
+// NewDecryptReader reads the envelope header from src and returns a reader of the plaintext
+// It returns ErrWrongKey if key cannot open the envelope. Reads fail with
+// ErrCorrupted if the data was modified or cut short.
+func NewDecryptReader(src io.Reader, key *Key) (io.Reader, error) {
+	raw := make([]byte, HeaderSize)
+	if _, err := io.ReadFull(src, raw); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, ErrCorrupted
+		}
+		return nil, err
+	}
+	h, err := parseHeader(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	kek, err := key.keyFor(h)
+	if err != nil {
+		return nil, err
+	}
+	wrap, err := kdf.NewGCM(kek)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := wrap.Open(nil, h.wrapNonce, h.wrappedKey, h.marshalPrefix())
+	if err != nil {
+		return nil, ErrWrongKey
+	}
+	aead, err := kdf.NewGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptReader{
+		src:    src,
+		aead:   aead,
+		prefix: h.noncePrefix,
+		chunk:  make([]byte, int(h.chunkSize)+tagSize),
+	}, nil
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.openNext(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// openNext reads and authenticates the next chunk
+// Only the last chunk is shorter than a full one, which is how it is recognised.
+func (r *decryptReader) openNext() error {
+	n, err := io.ReadFull(r.src, r.chunk)
+	final := err == io.ErrUnexpectedEOF
+	switch {
+	case err == io.EOF:
+		return ErrCorrupted
+	case err != nil && !final:
+		return err
+	case n < tagSize:
+		return ErrCorrupted
+	}
+
+	plain, err := r.aead.Open(r.chunk[:0], chunkNonce(r.prefix, r.counter, final), r.chunk[:n], nil)
+	if err != nil {
+		return ErrCorrupted
+	}
+	r.pending = plain
+	r.counter++
+	r.done = final
+	return nil
+}
+
+// randomBytes returns n bytes from the system's secure random source
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return b, nil
+}
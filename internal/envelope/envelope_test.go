@@ -0,0 +1,157 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package envelope
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testPassphraseKey returns a passphrase key with a low work factor to keep tests fast
+func testPassphraseKey(passphrase string) *Key {
+	k := NewPassphraseKey(func(bool) (string, error) { return passphrase, nil })
+	k.iterations = 1000
+	return k
+}
+
+func encrypt(t *testing.T, plaintext []byte, key *Key) []byte {
+	t.Helper()
+	r, err := NewEncryptReader(bytes.NewReader(plaintext), key)
+	if err != nil {
+		t.Fatalf("NewEncryptReader() error = %v", err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("encrypting: %v", err)
+	}
+	return data
+}
+
+func decrypt(data []byte, key *Key) ([]byte, error) {
+	r, err := NewDecryptReader(bytes.NewReader(data), key)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestRoundTrip(t *testing.T) {
+	key := testPassphraseKey("correct horse")
+	for _, size := range []int{0, 1, ChunkSize - 1, ChunkSize, 3*ChunkSize + 17} {
+		plaintext := make([]byte, size)
+		rand.Read(plaintext)
+
+		data := encrypt(t, plaintext, key)
+		if !IsEnvelope(data) {
+			t.Errorf("size %d: missing envelope header", size)
+		}
+		if int64(len(data)) != EncryptedSize(int64(size)) {
+			t.Errorf("size %d: envelope is %d bytes, EncryptedSize says %d", size, len(data), EncryptedSize(int64(size)))
+		}
+		if got := PlaintextSize(int64(len(data))); got != int64(size) {
+			t.Errorf("size %d: PlaintextSize() = %d", size, got)
+		}
+
+		got, err := decrypt(data, key)
+		if err != nil {
+			t.Fatalf("size %d: decrypt error = %v", size, err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Errorf("size %d: round trip changed the data", size)
+		}
+	}
+}
+
+func TestDecrypt_DetectsTampering(t *testing.T) {
+	key := testPassphraseKey("correct horse")
+	plaintext := bytes.Repeat([]byte("secret "), ChunkSize/3)
+	data := encrypt(t, plaintext, key)
+
+	flipped := append([]byte(nil), data...)
+	flipped[HeaderSize+10] ^= 1
+	if _, err := decrypt(flipped, key); !errors.Is(err, ErrCorrupted) {
+		t.Errorf("Modified chunk: error = %v, want ErrCorrupted", err)
+	}
+
+	// Dropping the final chunk leaves a stream that ends on a chunk boundary
+	truncated := data[:HeaderSize+ChunkSize+tagSize]
+	if _, err := decrypt(truncated, key); !errors.Is(err, ErrCorrupted) {
+		t.Errorf("Truncated envelope: error = %v, want ErrCorrupted", err)
+	}
+
+	if _, err := decrypt(data, testPassphraseKey("wrong")); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("Wrong passphrase: error = %v, want ErrWrongKey", err)
+	}
+}
+
+func TestEncryptReader_Seek(t *testing.T) {
+	key := testPassphraseKey("correct horse")
+	plaintext := []byte("rewind me")
+	r, err := NewEncryptReader(bytes.NewReader(plaintext), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.ReadAll(r)
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("Seek() error = %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	if got, err := decrypt(data, key); err != nil || !bytes.Equal(got, plaintext) {
+		t.Errorf("After rewinding: decrypt = %q, %v", got, err)
+	}
+}
+
+func TestLoadKeyFile(t *testing.T) {
+	raw := bytes.Repeat([]byte{0xab}, keySize)
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"raw.key": raw,
+		"hex.key": []byte("abababababababababababababababababababababababababababababababab\n"),
+		"b64.key": []byte("q6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6s=\n"),
+	}
+	plaintext := []byte("keyed")
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, content, 0600)
+		key, err := LoadKeyFile(path)
+		if err != nil {
+			t.Fatalf("%s: LoadKeyFile() error = %v", name, err)
+		}
+		if !bytes.Equal(key.raw, raw) {
+			t.Errorf("%s: loaded the wrong key", name)
+		}
+		if got, err := decrypt(encrypt(t, plaintext, key), key); err != nil || !bytes.Equal(got, plaintext) {
+			t.Errorf("%s: round trip = %q, %v", name, got, err)
+		}
+	}
+
+	short := filepath.Join(dir, "short.key")
+	os.WriteFile(short, []byte("too short"), 0600)
+	if _, err := LoadKeyFile(short); err == nil {
+		t.Error("Expected an error for a short key file")
+	}
+
+	// A key file cannot open a passphrase envelope
+	key, _ := NewRawKey(raw)
+	if _, err := decrypt(encrypt(t, plaintext, testPassphraseKey("pw")), key); err == nil {
+		t.Error("Expected an error when opening a passphrase envelope with a key file")
+	}
+}
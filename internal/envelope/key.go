@@ -0,0 +1,193 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package envelope
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/vijay-papanaboina/cloud-storage-api-cli/internal/kdf"
+)
+
+// PassphraseEnvVar supplies the encryption passphrase
+const PassphraseEnvVar = "CLOUD_STORAGE_ENCRYPTION_PASSPHRASE"
+
+// PassphraseFunc returns the encryption passphrase
+// confirm is true when encrypting, so the passphrase should be entered twice.
+type PassphraseFunc func(confirm bool) (string, error)
+
+// Key supplies the key-encryption key of envelopes, either from a key file or
+// derived from a passphrase. The passphrase is only asked for when a key is
+// first needed, and derived keys are cached, so one Key can safely be shared
+// by concurrent transfers.
+type Key struct {
+	mu         sync.Mutex
+	raw        []byte
+	passphrase PassphraseFunc
+	iterations int
+
+	// cached is the passphrase once it has been entered
+	cached string
+	// salt is used for every file encrypted with this key, so the passphrase is derived once
+	salt    []byte
+	derived map[string][]byte
+}
+
+// NewPassphraseKey returns a key derived from the passphrase returned by fn
+func NewPassphraseKey(fn PassphraseFunc) *Key {
+	return &Key{passphrase: fn, iterations: kdf.Iterations, derived: map[string][]byte{}}
+}
+
+// NewRawKey returns a key that uses 32 bytes of key material directly
+func NewRawKey(raw []byte) (*Key, error) {
+	if len(raw) != keySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", keySize, len(raw))
+	}
+	return &Key{raw: raw}, nil
+}
+
+// LoadKeyFile reads a key file holding 32 raw bytes, or 32 bytes encoded as hex or base64
+func LoadKeyFile(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	if len(data) == keySize {
+		return NewRawKey(data)
+	}
+	text := strings.TrimSpace(string(data))
+	if raw, err := hex.DecodeString(text); err == nil && len(raw) == keySize {
+		return NewRawKey(raw)
+	}
+	if raw, err := base64.StdEncoding.DecodeString(text); err == nil && len(raw) == keySize {
+		return NewRawKey(raw)
+	}
+	return nil, fmt.Errorf("invalid key file %s: expected %d bytes, raw or as hex or base64", path, keySize)
+}
+
+// newHeader returns the header and data key of a new envelope
+func (k *Key) newHeader() (*header, []byte, error) {
+	h := &header{chunkSize: ChunkSize}
+	if k.raw != nil {
+		h.kdf = kdfRawKey
+		h.salt = make([]byte, saltSize)
+	} else {
+		k.mu.Lock()
+		if k.salt == nil {
+			salt, err := kdf.NewSalt()
+			if err != nil {
+				k.mu.Unlock()
+				return nil, nil, err
+			}
+			k.salt = salt
+		}
+		h.kdf, h.salt, h.iterations = kdfPBKDF2, k.salt, uint32(k.iterations)
+		k.mu.Unlock()
+	}
+
+	var err error
+	if h.noncePrefix, err = randomBytes(noncePrefixSize); err != nil {
+		return nil, nil, err
+	}
+	if h.wrapNonce, err = randomBytes(nonceSize); err != nil {
+		return nil, nil, err
+	}
+	dataKey, err := randomBytes(keySize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kek, err := k.derive(h, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	wrap, err := kdf.NewGCM(kek)
+	if err != nil {
+		return nil, nil, err
+	}
+	h.wrappedKey = wrap.Seal(nil, h.wrapNonce, dataKey, h.marshalPrefix())
+	return h, dataKey, nil
+}
+
+// keyFor returns the key-encryption key that opens an existing envelope
+func (k *Key) keyFor(h *header) ([]byte, error) {
+	switch {
+	case h.kdf == kdfRawKey && k.raw == nil:
+		return nil, fmt.Errorf("file was encrypted with a key file, not a passphrase")
+	case h.kdf == kdfPBKDF2 && k.raw != nil:
+		return nil, fmt.Errorf("file was encrypted with a passphrase, not a key file")
+	case h.kdf != kdfRawKey && h.kdf != kdfPBKDF2:
+		return nil, fmt.Errorf("unsupported key derivation in encrypted file: %d", h.kdf)
+	case h.kdf == kdfPBKDF2 && !kdf.ValidIterations(int(h.iterations)):
+		return nil, fmt.Errorf("invalid encrypted file: bad key derivation parameters")
+	}
+	return k.derive(h, false)
+}
+
+// derive returns the key-encryption key for the salt and work factor in h
+func (k *Key) derive(h *header, confirm bool) ([]byte, error) {
+	if k.raw != nil {
+		return k.raw, nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	id := fmt.Sprintf("%x/%d", h.salt, h.iterations)
+	if kek, ok := k.derived[id]; ok {
+		return kek, nil
+	}
+	if err := k.unlock(confirm); err != nil {
+		return nil, err
+	}
+	kek, err := kdf.DeriveKey(k.cached, h.salt, int(h.iterations))
+	if err != nil {
+		return nil, err
+	}
+	k.derived[id] = kek
+	return kek, nil
+}
+
+// Unlock asks for the passphrase now instead of when a key is first needed
+// This keeps the prompt away from progress bars. Key files need no unlocking.
+func (k *Key) Unlock(confirm bool) error {
+	if k.raw != nil {
+		return nil
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.unlock(confirm)
+}
+
+// unlock asks for the passphrase unless it was already entered; k.mu must be held
+func (k *Key) unlock(confirm bool) error {
+	if k.cached != "" {
+		return nil
+	}
+	passphrase, err := k.passphrase(confirm)
+	if err != nil {
+		return err
+	}
+	if passphrase == "" {
+		return fmt.Errorf("passphrase cannot be empty")
+	}
+	k.cached = passphrase
+	return nil
+}
@@ -30,5 +30,37 @@ type BatchJobResponse struct {
 	EstimatedCompletion *time.Time `json:"estimatedCompletion,omitempty"`
 	ErrorMessage       string     `json:"errorMessage,omitempty"`
 	StatusURL          string     `json:"statusUrl,omitempty"`
+	Items              []BatchItemResult `json:"items,omitempty"`
+}
+
+// BatchItemResult represents the outcome of a single item of a batch job
+type BatchItemResult struct {
+	ItemID       string `json:"itemId"`
+	FileID       string `json:"fileId,omitempty"`
+	Path         string `json:"path,omitempty"`
+	Status       string `json:"status"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// BatchPageResponse represents a paginated list of batch jobs from the API
+type BatchPageResponse struct {
+	Content          []BatchJobResponse `json:"content"`
+	Pageable         PageableResponse   `json:"pageable"`
+	TotalElements    int64              `json:"totalElements"`
+	TotalPages       int                `json:"totalPages"`
+	First            bool               `json:"first"`
+	Last             bool               `json:"last"`
+	NumberOfElements int                `json:"numberOfElements"`
+}
+
+// BatchDeleteRequest represents a request to delete several files in one batch job
+type BatchDeleteRequest struct {
+	FileIDs []string `json:"fileIds"`
+}
+
+// BatchMoveRequest represents a request to move several files into a folder in one batch job
+type BatchMoveRequest struct {
+	FileIDs    []string `json:"fileIds"`
+	FolderPath string   `json:"folderPath"`
 }
 
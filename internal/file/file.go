@@ -26,6 +26,7 @@ type FileResponse struct {
 	FolderPath          *string   `json:"folderPath,omitempty"`
 	CloudinaryUrl       string    `json:"cloudinaryUrl"`
 	CloudinarySecureUrl string    `json:"cloudinarySecureUrl"`
+	Checksum            string    `json:"checksum,omitempty"` // SHA-256 of the content, if the server reports one
 	CreatedAt           time.Time `json:"createdAt"`
 	UpdatedAt           time.Time `json:"updatedAt"`
 }
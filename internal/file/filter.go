@@ -0,0 +1,83 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package file
+
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+// Filter selects files by attributes the API cannot filter on
+// Zero values disable a condition; MaxSize is only applied when HasMaxSize is set.
+type Filter struct {
+	MinSize       int64
+	MaxSize       int64
+	HasMaxSize    bool
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	UpdatedSince  time.Time
+	// NameGlob is a path.Match pattern matched against the filename
+	NameGlob string
+}
+
+// Validate checks that the filter's conditions can be satisfied and its glob is well-formed
+func (f *Filter) Validate() error {
+	if f.HasMaxSize && f.MaxSize < f.MinSize {
+		return fmt.Errorf("maximum size must not be smaller than minimum size")
+	}
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && !f.CreatedBefore.After(f.CreatedAfter) {
+		return fmt.Errorf("created-before must be later than created-after")
+	}
+	if f.NameGlob != "" {
+		if _, err := path.Match(f.NameGlob, ""); err != nil {
+			return fmt.Errorf("invalid name glob %q: %w", f.NameGlob, err)
+		}
+	}
+	return nil
+}
+
+// IsEmpty reports whether the filter matches every file
+func (f *Filter) IsEmpty() bool {
+	return f.MinSize == 0 && !f.HasMaxSize &&
+		f.CreatedAfter.IsZero() && f.CreatedBefore.IsZero() && f.UpdatedSince.IsZero() &&
+		f.NameGlob == ""
+}
+
+// Match reports whether a file satisfies every condition of the filter
+func (f *Filter) Match(fr *FileResponse) bool {
+	if fr.FileSize < f.MinSize {
+		return false
+	}
+	if f.HasMaxSize && fr.FileSize > f.MaxSize {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && fr.CreatedAt.Before(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !fr.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+	if !f.UpdatedSince.IsZero() && fr.UpdatedAt.Before(f.UpdatedSince) {
+		return false
+	}
+	if f.NameGlob != "" {
+		if ok, _ := path.Match(f.NameGlob, fr.Filename); !ok {
+			return false
+		}
+	}
+	return true
+}
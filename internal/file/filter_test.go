@@ -0,0 +1,87 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package file
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilter_Match(t *testing.T) {
+	jan := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC)
+	f := &FileResponse{Filename: "report-2025.pdf", FileSize: 2048, CreatedAt: jan, UpdatedAt: feb}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"empty", Filter{}, true},
+		{"min size", Filter{MinSize: 2048}, true},
+		{"min size too large", Filter{MinSize: 2049}, false},
+		{"max size", Filter{MaxSize: 2048, HasMaxSize: true}, true},
+		{"max size too small", Filter{MaxSize: 1024, HasMaxSize: true}, false},
+		{"max size zero", Filter{HasMaxSize: true}, false},
+		{"created after", Filter{CreatedAfter: jan.Add(-time.Hour)}, true},
+		{"created after too late", Filter{CreatedAfter: jan.Add(time.Hour)}, false},
+		{"created before", Filter{CreatedBefore: jan.Add(time.Hour)}, true},
+		{"created before is exclusive", Filter{CreatedBefore: jan}, false},
+		{"updated since", Filter{UpdatedSince: feb}, true},
+		{"updated since too late", Filter{UpdatedSince: feb.Add(time.Second)}, false},
+		{"glob", Filter{NameGlob: "report-*.pdf"}, true},
+		{"glob mismatch", Filter{NameGlob: "*.txt"}, false},
+		{"combined", Filter{MinSize: 1024, NameGlob: "*.pdf", UpdatedSince: jan}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(f); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter_Validate(t *testing.T) {
+	jan := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	valid := []Filter{
+		{},
+		{MinSize: 10, MaxSize: 10, HasMaxSize: true},
+		{CreatedAfter: jan, CreatedBefore: jan.Add(time.Hour)},
+		{NameGlob: "*.jpg"},
+	}
+	for _, f := range valid {
+		if err := f.Validate(); err != nil {
+			t.Errorf("Validate(%+v) error = %v", f, err)
+		}
+	}
+
+	invalid := []Filter{
+		{MinSize: 10, MaxSize: 5, HasMaxSize: true},
+		{CreatedAfter: jan, CreatedBefore: jan},
+		{NameGlob: "[a-"},
+	}
+	for _, f := range invalid {
+		if err := f.Validate(); err == nil {
+			t.Errorf("Validate(%+v) expected error, got nil", f)
+		}
+	}
+	if !(&Filter{}).IsEmpty() || (&Filter{NameGlob: "*"}).IsEmpty() {
+		t.Error("IsEmpty() classified filters incorrectly")
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kdf turns passphrases into AES-256-GCM ciphers
+//
+// It is shared by the encrypted credential store and client-side file
+// encryption, so both derive keys with the same PBKDF2-SHA256 parameters.
+package kdf
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+)
+
+const (
+	// Iterations is the PBKDF2 work factor used for new keys
+	Iterations = 600000
+	// MaxIterations bounds the work factor accepted from a stored file
+	MaxIterations = 10000000
+	// SaltSize is the length of a random salt
+	SaltSize = 16
+	// KeySize is the length of a derived key (AES-256)
+	KeySize = 32
+)
+
+// ValidIterations reports whether a work factor read from a file may be used
+func ValidIterations(iterations int) bool {
+	return iterations > 0 && iterations <= MaxIterations
+}
+
+// NewSalt returns a random salt of SaltSize bytes
+func NewSalt() ([]byte, error) {
+	salt := make([]byte, SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return salt, nil
+}
+
+// DeriveKey derives a key of KeySize bytes from passphrase with PBKDF2-SHA256
+func DeriveKey(passphrase string, salt []byte, iterations int) ([]byte, error) {
+	if !ValidIterations(iterations) {
+		return nil, fmt.Errorf("invalid key derivation work factor: %d", iterations)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive encryption key: %w", err)
+	}
+	return key, nil
+}
+
+// NewGCM returns an AES-256-GCM cipher for key
+func NewGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return gcm, nil
+}
+
+// NewCipher derives a key from passphrase and returns an AES-256-GCM cipher for it
+func NewCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := DeriveKey(passphrase, salt, iterations)
+	if err != nil {
+		return nil, err
+	}
+	return NewGCM(key)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kdf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDeriveKey(t *testing.T) {
+	salt := bytes.Repeat([]byte{1}, SaltSize)
+	a, err := DeriveKey("passphrase", salt, 1000)
+	if err != nil {
+		t.Fatalf("DeriveKey() error = %v", err)
+	}
+	b, _ := DeriveKey("passphrase", salt, 1000)
+	c, _ := DeriveKey("other", salt, 1000)
+	if len(a) != KeySize || !bytes.Equal(a, b) || bytes.Equal(a, c) {
+		t.Error("Expected the same key for the same passphrase and salt only")
+	}
+
+	// Work factors read from a file are bounded
+	for _, iterations := range []int{0, -1, MaxIterations + 1} {
+		if _, err := DeriveKey("passphrase", salt, iterations); err == nil {
+			t.Errorf("DeriveKey() with %d iterations expected error, got nil", iterations)
+		}
+	}
+}
+
+func TestNewCipher(t *testing.T) {
+	salt, err := NewSalt()
+	if err != nil {
+		t.Fatalf("NewSalt() error = %v", err)
+	}
+	gcm, err := NewCipher("passphrase", salt, 1000)
+	if err != nil {
+		t.Fatalf("NewCipher() error = %v", err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	sealed := gcm.Seal(nil, nonce, []byte("secret"), nil)
+
+	other, _ := NewCipher("wrong", salt, 1000)
+	if _, err := other.Open(nil, nonce, sealed, nil); err == nil {
+		t.Error("Expected a different passphrase to fail to decrypt")
+	}
+	opened, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil || string(opened) != "secret" {
+		t.Errorf("Open() = %q, %v", opened, err)
+	}
+}
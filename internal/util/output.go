@@ -0,0 +1,670 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package util
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output format names
+const (
+	FormatTable    = "table"
+	FormatWide     = "wide"
+	FormatJSON     = "json"
+	FormatNDJSON   = "ndjson"
+	FormatCSV      = "csv"
+	FormatYAML     = "yaml"
+	FormatTemplate = "template"
+)
+
+// Column describes a column of tabular output
+type Column struct {
+	Header string
+	// Width is the padded width in table output, where longer values are
+	// truncated; wide output never truncates (0 means no limit)
+	Width int
+	// Wide columns are only shown by the wide and csv formats
+	Wide bool
+}
+
+// Table holds tabular data for the table, wide and csv formats
+type Table struct {
+	Columns []Column
+	Rows    [][]string
+}
+
+// Result is a command result to be rendered in the selected output format
+type Result struct {
+	// Data is rendered by the json, yaml and template formats
+	Data interface{}
+	// Items optionally selects the list inside Data (e.g. a page's content);
+	// ndjson, csv and template then render it item by item
+	Items interface{}
+	// Table is rendered by the wide and csv formats, and by table when Text is nil
+	Table *Table
+	// Text prints the human-readable form for the table format (and wide without a Table)
+	Text func()
+}
+
+// Formatter renders results in one output format
+type Formatter interface {
+	// Print renders a complete result
+	Print(w io.Writer, r *Result) error
+	// Stream starts rendering a list whose items arrive one at a time
+	Stream(w io.Writer, columns []Column) ItemStream
+}
+
+// ItemStream renders list items as they arrive
+type ItemStream interface {
+	// Write renders one item; row holds its cells for the table, wide and csv formats
+	Write(item interface{}, row []string) error
+	// Close finishes the output
+	Close() error
+}
+
+// FormatFactory creates a formatter; arg is the text after "=" in "name=arg"
+type FormatFactory func(arg string) (Formatter, error)
+
+var (
+	formatsMu sync.RWMutex
+	formats   = map[string]FormatFactory{}
+)
+
+func init() {
+	RegisterFormat(FormatTable, func(string) (Formatter, error) { return tableFormatter{}, nil })
+	RegisterFormat(FormatWide, func(string) (Formatter, error) { return tableFormatter{wide: true}, nil })
+	RegisterFormat(FormatJSON, func(string) (Formatter, error) { return jsonFormatter{}, nil })
+	RegisterFormat(FormatNDJSON, func(string) (Formatter, error) { return ndjsonFormatter{}, nil })
+	RegisterFormat(FormatCSV, func(string) (Formatter, error) { return csvFormatter{}, nil })
+	RegisterFormat(FormatYAML, func(string) (Formatter, error) { return yamlFormatter{}, nil })
+	RegisterFormat(FormatTemplate, newTemplateFormatter)
+}
+
+// RegisterFormat makes an output format selectable by name
+func RegisterFormat(name string, factory FormatFactory) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[name] = factory
+}
+
+// FormatNames returns the names of all registered output formats
+func FormatNames() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewFormatter returns the formatter for an --output value such as "json"
+// or "template={{.id}}"
+func NewFormatter(spec string) (Formatter, error) {
+	name, arg, _ := strings.Cut(spec, "=")
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	formatsMu.RLock()
+	factory, ok := formats[name]
+	formatsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown output format: %s (available: %s)", spec, strings.Join(FormatNames(), ", "))
+	}
+	return factory(arg)
+}
+
+// IsHumanFormat reports whether an --output value selects human-readable output
+func IsHumanFormat(spec string) bool {
+	name, _, _ := strings.Cut(spec, "=")
+	name = strings.ToLower(strings.TrimSpace(name))
+	return name == FormatTable || name == FormatWide
+}
+
+// toGeneric converts v to maps, slices and scalars using its JSON encoding,
+// so every format uses the same field names as --output json
+func toGeneric(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+	return generic, nil
+}
+
+// listItems returns the elements of r.Items (or r.Data) when it is a slice
+func listItems(r *Result) ([]interface{}, bool) {
+	v := r.Items
+	if v == nil {
+		v = r.Data
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, true
+}
+
+// tableFormatter renders fixed-width columns, or the command's own text for the table format
+type tableFormatter struct {
+	wide bool
+}
+
+func (f tableFormatter) Print(w io.Writer, r *Result) error {
+	if r.Text != nil && (!f.wide || r.Table == nil) {
+		r.Text()
+		return nil
+	}
+	if r.Table != nil {
+		if len(r.Table.Rows) == 0 {
+			fmt.Fprintln(w, "No results found.")
+			return nil
+		}
+		columns := visibleColumns(r.Table.Columns, f.wide)
+		rows := make([][]string, len(r.Table.Rows))
+		for i, row := range r.Table.Rows {
+			rows[i] = visibleCells(r.Table.Columns, row, f.wide)
+		}
+		if f.wide {
+			columns = fitColumns(columns, rows)
+		}
+		writeTableRow(w, columns, headerRow(columns), !f.wide)
+		fmt.Fprintln(w, strings.Repeat("-", tableWidth(columns)))
+		for _, row := range rows {
+			writeTableRow(w, columns, row, !f.wide)
+		}
+		return nil
+	}
+	return jsonFormatter{}.Print(w, r)
+}
+
+func (f tableFormatter) Stream(w io.Writer, columns []Column) ItemStream {
+	return &tableStream{w: w, all: columns, columns: visibleColumns(columns, f.wide), wide: f.wide}
+}
+
+type tableStream struct {
+	w       io.Writer
+	all     []Column
+	columns []Column
+	wide    bool
+	count   int
+}
+
+func (s *tableStream) Write(item interface{}, row []string) error {
+	if s.count == 0 {
+		writeTableRow(s.w, s.columns, headerRow(s.columns), !s.wide)
+		fmt.Fprintln(s.w, strings.Repeat("-", tableWidth(s.columns)))
+	}
+	s.count++
+	writeTableRow(s.w, s.columns, visibleCells(s.all, row, s.wide), !s.wide)
+	return nil
+}
+
+func (s *tableStream) Close() error {
+	return nil
+}
+
+// visibleColumns returns the columns shown by the table (or wide) format
+func visibleColumns(columns []Column, wide bool) []Column {
+	var visible []Column
+	for _, c := range columns {
+		if wide || !c.Wide {
+			visible = append(visible, c)
+		}
+	}
+	return visible
+}
+
+// visibleCells returns the cells of row belonging to visible columns
+func visibleCells(columns []Column, row []string, wide bool) []string {
+	var cells []string
+	for i, c := range columns {
+		if wide || !c.Wide {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			cells = append(cells, cell)
+		}
+	}
+	return cells
+}
+
+func headerRow(columns []Column) []string {
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.Header
+	}
+	return header
+}
+
+func tableWidth(columns []Column) int {
+	width := 0
+	for i, c := range columns {
+		w := c.Width
+		if w == 0 {
+			w = len(c.Header)
+		}
+		width += w
+		if i > 0 {
+			width++
+		}
+	}
+	return width
+}
+
+// fitColumns widens columns to their longest cell, for untruncated wide output
+func fitColumns(columns []Column, rows [][]string) []Column {
+	fitted := make([]Column, len(columns))
+	copy(fitted, columns)
+	for i := range fitted {
+		if len(fitted[i].Header) > fitted[i].Width {
+			fitted[i].Width = len(fitted[i].Header)
+		}
+		for _, row := range rows {
+			if i < len(row) && len(row[i]) > fitted[i].Width {
+				fitted[i].Width = len(row[i])
+			}
+		}
+	}
+	return fitted
+}
+
+// writeTableRow writes cells padded to their column widths, truncating long
+// cells when truncate is set
+func writeTableRow(w io.Writer, columns []Column, cells []string, truncate bool) {
+	var b strings.Builder
+	for i, c := range columns {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		if truncate && c.Width > 0 && len(cell) > c.Width {
+			if c.Width > 3 {
+				cell = cell[:c.Width-3] + "..."
+			} else {
+				cell = cell[:c.Width]
+			}
+		}
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		if i < len(columns)-1 {
+			fmt.Fprintf(&b, "%-*s", c.Width, cell)
+		} else {
+			b.WriteString(cell)
+		}
+	}
+	fmt.Fprintln(w, b.String())
+}
+
+// jsonFormatter renders indented JSON
+type jsonFormatter struct{}
+
+func (jsonFormatter) Print(w io.Writer, r *Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r.Data); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
+
+func (jsonFormatter) Stream(w io.Writer, columns []Column) ItemStream {
+	return &jsonStream{w: w}
+}
+
+// jsonStream writes a JSON array one element at a time
+type jsonStream struct {
+	w     io.Writer
+	count int
+}
+
+func (s *jsonStream) Write(item interface{}, row []string) error {
+	data, err := json.MarshalIndent(item, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	sep := ",\n  "
+	if s.count == 0 {
+		sep = "[\n  "
+	}
+	s.count++
+	_, err = fmt.Fprintf(s.w, "%s%s", sep, data)
+	return err
+}
+
+func (s *jsonStream) Close() error {
+	if s.count == 0 {
+		_, err := fmt.Fprintln(s.w, "[]")
+		return err
+	}
+	_, err := fmt.Fprintln(s.w, "\n]")
+	return err
+}
+
+// ndjsonFormatter renders one compact JSON value per line
+type ndjsonFormatter struct{}
+
+func (ndjsonFormatter) Print(w io.Writer, r *Result) error {
+	items, ok := listItems(r)
+	if !ok {
+		items = []interface{}{r.Data}
+	}
+	encoder := json.NewEncoder(w)
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+	}
+	return nil
+}
+
+func (ndjsonFormatter) Stream(w io.Writer, columns []Column) ItemStream {
+	return &ndjsonStream{encoder: json.NewEncoder(w)}
+}
+
+type ndjsonStream struct {
+	encoder *json.Encoder
+}
+
+func (s *ndjsonStream) Write(item interface{}, row []string) error {
+	if err := s.encoder.Encode(item); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
+
+func (s *ndjsonStream) Close() error {
+	return nil
+}
+
+// csvFormatter renders comma-separated values with a header row
+type csvFormatter struct{}
+
+func (csvFormatter) Print(w io.Writer, r *Result) error {
+	cw := csv.NewWriter(w)
+	if r.Table != nil {
+		cw.Write(headerRow(r.Table.Columns))
+		for _, row := range r.Table.Rows {
+			cw.Write(row)
+		}
+	} else {
+		// Without a table, flatten the JSON form: one row per object
+		items, ok := listItems(r)
+		if !ok {
+			items = []interface{}{r.Data}
+		}
+		if err := writeGenericCSV(cw, items); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (csvFormatter) Stream(w io.Writer, columns []Column) ItemStream {
+	return &csvStream{w: csv.NewWriter(w), columns: columns}
+}
+
+type csvStream struct {
+	w       *csv.Writer
+	columns []Column
+	started bool
+}
+
+func (s *csvStream) Write(item interface{}, row []string) error {
+	if !s.started {
+		s.w.Write(headerRow(s.columns))
+		s.started = true
+	}
+	s.w.Write(row)
+	// Flush per row so output appears as items arrive
+	s.w.Flush()
+	return s.w.Error()
+}
+
+func (s *csvStream) Close() error {
+	if !s.started {
+		s.w.Write(headerRow(s.columns))
+	}
+	s.w.Flush()
+	return s.w.Error()
+}
+
+// writeGenericCSV writes objects as rows, using the sorted union of their keys as header
+func writeGenericCSV(cw *csv.Writer, items []interface{}) error {
+	var rows []map[string]interface{}
+	keySet := map[string]bool{}
+	for _, item := range items {
+		generic, err := toGeneric(item)
+		if err != nil {
+			return err
+		}
+		obj, ok := generic.(map[string]interface{})
+		if !ok {
+			obj = map[string]interface{}{"value": generic}
+		}
+		for k := range obj {
+			keySet[k] = true
+		}
+		rows = append(rows, obj)
+	}
+
+	keys := make([]string, 0, len(keySet))
+	for k := range keySet {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	cw.Write(keys)
+	for _, obj := range rows {
+		record := make([]string, len(keys))
+		for i, k := range keys {
+			record[i] = csvCell(obj[k])
+		}
+		cw.Write(record)
+	}
+	return nil
+}
+
+// csvCell formats a generic JSON value as a CSV cell; nested values stay JSON
+func csvCell(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return fmt.Sprintf("%t", value)
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+}
+
+// yamlFormatter renders YAML with the same field names as JSON
+type yamlFormatter struct{}
+
+func (yamlFormatter) Print(w io.Writer, r *Result) error {
+	generic, err := toGeneric(r.Data)
+	if err != nil {
+		return err
+	}
+	return writeYAML(w, generic)
+}
+
+func (yamlFormatter) Stream(w io.Writer, columns []Column) ItemStream {
+	return &yamlStream{w: w}
+}
+
+// yamlStream writes a YAML sequence one element at a time
+type yamlStream struct {
+	w     io.Writer
+	count int
+}
+
+func (s *yamlStream) Write(item interface{}, row []string) error {
+	generic, err := toGeneric(item)
+	if err != nil {
+		return err
+	}
+	s.count++
+	return writeYAML(s.w, []interface{}{generic})
+}
+
+func (s *yamlStream) Close() error {
+	if s.count == 0 {
+		_, err := fmt.Fprintln(s.w, "[]")
+		return err
+	}
+	return nil
+}
+
+func writeYAML(w io.Writer, v interface{}) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(yamlNumbers(v)); err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return encoder.Close()
+}
+
+// yamlNumbers replaces json.Number values with ints or floats, which the YAML
+// encoder would otherwise quote as strings
+func yamlNumbers(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			value[k] = yamlNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = yamlNumbers(item)
+		}
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			return n
+		}
+		if f, err := value.Float64(); err == nil {
+			return f
+		}
+	}
+	return v
+}
+
+// templateFormatter renders a Go template against the JSON form of the result
+// Field names are the JSON names, e.g. {{.filename}}. Lists are rendered once
+// per item, with a newline added when the template does not end with one.
+type templateFormatter struct {
+	tmpl *template.Template
+}
+
+// templateFuncs are available in --output template
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"size": func(v interface{}) string {
+		if n, ok := v.(json.Number); ok {
+			if size, err := n.Int64(); err == nil {
+				return FormatFileSize(size)
+			}
+		}
+		return fmt.Sprint(v)
+	},
+}
+
+func newTemplateFormatter(text string) (Formatter, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("template output requires a template, e.g. -o 'template={{.id}}'")
+	}
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	tmpl, err := template.New("output").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+	return templateFormatter{tmpl: tmpl}, nil
+}
+
+func (f templateFormatter) Print(w io.Writer, r *Result) error {
+	items, ok := listItems(r)
+	if !ok || r.Items == nil {
+		items = []interface{}{r.Data}
+	}
+	for _, item := range items {
+		if err := f.execute(w, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f templateFormatter) Stream(w io.Writer, columns []Column) ItemStream {
+	return &templateStream{w: w, f: f}
+}
+
+type templateStream struct {
+	w io.Writer
+	f templateFormatter
+}
+
+func (s *templateStream) Write(item interface{}, row []string) error {
+	return s.f.execute(s.w, item)
+}
+
+func (s *templateStream) Close() error {
+	return nil
+}
+
+func (f templateFormatter) execute(w io.Writer, v interface{}) error {
+	generic, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+	if err := f.tmpl.Execute(w, generic); err != nil {
+		return fmt.Errorf("failed to render output template: %w", err)
+	}
+	return nil
+}
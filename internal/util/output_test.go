@@ -0,0 +1,159 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package util
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type testItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+func testResult() *Result {
+	items := []testItem{{ID: "1", Name: "a.txt", Size: 10}, {ID: "2", Name: "b,c.txt", Size: 2048}}
+	table := &Table{
+		Columns: []Column{
+			{Header: "ID", Width: 4},
+			{Header: "NAME", Width: 6},
+			{Header: "SIZE", Wide: true},
+		},
+	}
+	for _, item := range items {
+		table.Rows = append(table.Rows, []string{item.ID, item.Name, FormatFileSize(item.Size)})
+	}
+	return &Result{
+		Data:  map[string]interface{}{"content": items, "total": 2},
+		Items: items,
+		Table: table,
+	}
+}
+
+func render(t *testing.T, spec string, r *Result) string {
+	t.Helper()
+	f, err := NewFormatter(spec)
+	if err != nil {
+		t.Fatalf("NewFormatter(%q) error = %v", spec, err)
+	}
+	var buf bytes.Buffer
+	if err := f.Print(&buf, r); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+	return buf.String()
+}
+
+func TestFormatters(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"table", "ID   NAME\n-----------\n1    a.txt\n2    b,c...\n"},
+		{"wide", "ID   NAME    SIZE\n-------------------\n1    a.txt   10 B\n2    b,c.txt 2.0 KB\n"},
+		{"csv", "ID,NAME,SIZE\n1,a.txt,10 B\n2,\"b,c.txt\",2.0 KB\n"},
+		{"ndjson", "{\"id\":\"1\",\"name\":\"a.txt\",\"size\":10}\n{\"id\":\"2\",\"name\":\"b,c.txt\",\"size\":2048}\n"},
+		{"template={{.name}}:{{size .size}}", "a.txt:10 B\nb,c.txt:2.0 KB\n"},
+		{"yaml", "content:\n  - id: \"1\"\n    name: a.txt\n    size: 10\n  - id: \"2\"\n    name: b,c.txt\n    size: 2048\ntotal: 2\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			if got := render(t, tt.spec, testResult()); got != tt.want {
+				t.Errorf("output mismatch\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatters_WithoutTable(t *testing.T) {
+	r := &Result{Data: testItem{ID: "1", Name: "a.txt", Size: 10}}
+
+	if got := render(t, "json", r); !strings.Contains(got, "\"name\": \"a.txt\"") {
+		t.Errorf("json output = %q", got)
+	}
+	if got, want := render(t, "csv", r), "id,name,size\n1,a.txt,10\n"; got != want {
+		t.Errorf("csv output = %q, want %q", got, want)
+	}
+	if got, want := render(t, "template={{.id}}", r), "1\n"; got != want {
+		t.Errorf("template output = %q, want %q", got, want)
+	}
+
+	called := false
+	r.Text = func() { called = true }
+	render(t, "table", r)
+	if !called {
+		t.Error("table format did not use the result's Text")
+	}
+}
+
+func TestFormatterStreams(t *testing.T) {
+	columns := []Column{{Header: "ID", Width: 4}, {Header: "NAME"}}
+	items := []testItem{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}}
+
+	tests := []struct {
+		spec  string
+		want  string
+		empty string
+	}{
+		{"json", "[\n  {\n    \"id\": \"1\",\n    \"name\": \"a\",\n    \"size\": 0\n  },\n  {\n    \"id\": \"2\",\n    \"name\": \"b\",\n    \"size\": 0\n  }\n]\n", "[]\n"},
+		{"csv", "ID,NAME\n1,a\n2,b\n", "ID,NAME\n"},
+		{"yaml", "- id: \"1\"\n  name: a\n  size: 0\n- id: \"2\"\n  name: b\n  size: 0\n", "[]\n"},
+		{"table", "ID   NAME\n---------\n1    a\n2    b\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			f, err := NewFormatter(tt.spec)
+			if err != nil {
+				t.Fatalf("NewFormatter() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			stream := f.Stream(&buf, columns)
+			for _, item := range items {
+				if err := stream.Write(item, []string{item.ID, item.Name}); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			if err := stream.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("stream output mismatch\ngot:\n%q\nwant:\n%q", buf.String(), tt.want)
+			}
+
+			buf.Reset()
+			if err := f.Stream(&buf, columns).Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if buf.String() != tt.empty {
+				t.Errorf("empty stream output = %q, want %q", buf.String(), tt.empty)
+			}
+		})
+	}
+}
+
+func TestNewFormatter_Errors(t *testing.T) {
+	for _, spec := range []string{"xml", "template=", "template={{.id"} {
+		if _, err := NewFormatter(spec); err == nil {
+			t.Errorf("NewFormatter(%q) expected error, got nil", spec)
+		}
+	}
+	if !IsHumanFormat("wide") || IsHumanFormat("template={{.id}}") {
+		t.Error("IsHumanFormat() classified formats incorrectly")
+	}
+}
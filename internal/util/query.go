@@ -0,0 +1,161 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+// NewQueryFormatter returns a formatter that applies a JMESPath expression to
+// the JSON form of each result before rendering it with f
+// Streamed lists are collected and queried when the stream is closed, as an
+// object whose "content" holds the items. That is the shape of a single page,
+// so a query such as content[].id means the same with and without paging; the
+// price is that a queried listing is held in memory rather than streamed.
+// With human (table or wide) output, the query result is printed as plain
+// values: strings and numbers one per line, anything else as JSON.
+func NewQueryFormatter(f Formatter, human bool, expression string) (Formatter, error) {
+	query, err := jmespath.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	return queryFormatter{inner: f, human: human, query: query}, nil
+}
+
+type queryFormatter struct {
+	inner Formatter
+	human bool
+	query *jmespath.JMESPath
+}
+
+func (f queryFormatter) Print(w io.Writer, r *Result) error {
+	data, err := toQueryData(r.Data)
+	if err != nil {
+		return err
+	}
+	return f.printQueried(w, data)
+}
+
+func (f queryFormatter) Stream(w io.Writer, columns []Column) ItemStream {
+	return &queryStream{w: w, f: f, items: []interface{}{}}
+}
+
+// printQueried runs the query against data and renders the result
+func (f queryFormatter) printQueried(w io.Writer, data interface{}) error {
+	result, err := f.query.Search(data)
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+	if f.human {
+		return printPlain(w, result)
+	}
+
+	// Re-encode so numbers render exactly as in unqueried output
+	result, err = toGeneric(result)
+	if err != nil {
+		return err
+	}
+	queried := &Result{Data: result}
+	if items, ok := result.([]interface{}); ok {
+		queried.Items = items
+	}
+	return f.inner.Print(w, queried)
+}
+
+// queryStream collects streamed items so the query sees the whole list
+type queryStream struct {
+	w     io.Writer
+	f     queryFormatter
+	items []interface{}
+}
+
+func (s *queryStream) Write(item interface{}, row []string) error {
+	data, err := toQueryData(item)
+	if err != nil {
+		return err
+	}
+	s.items = append(s.items, data)
+	return nil
+}
+
+func (s *queryStream) Close() error {
+	return s.f.printQueried(s.w, map[string]interface{}{"content": s.items})
+}
+
+// toQueryData converts v to the generic form JMESPath works on
+// Numbers are decoded as float64, which is what JMESPath comparisons expect.
+func toQueryData(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+	return generic, nil
+}
+
+// printPlain prints a query result for human output
+func printPlain(w io.Writer, v interface{}) error {
+	switch value := v.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		for _, item := range value {
+			if !isScalar(item) {
+				return printIndentedJSON(w, v)
+			}
+		}
+		for _, item := range value {
+			if err := printPlain(w, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]interface{}:
+		return printIndentedJSON(w, v)
+	case float64:
+		_, err := fmt.Fprintln(w, strconv.FormatFloat(value, 'f', -1, 64))
+		return err
+	default:
+		_, err := fmt.Fprintln(w, value)
+		return err
+	}
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	default:
+		return true
+	}
+}
+
+func printIndentedJSON(w io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
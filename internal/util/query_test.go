@@ -0,0 +1,89 @@
+/*
+Copyright © 2025 vijay papanaboina
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package util
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestQueryFormatter(t *testing.T) {
+	tests := []struct {
+		format string
+		human  bool
+		query  string
+		want   string
+	}{
+		{"table", true, "content[?size > `1000`].name", "b,c.txt\n"},
+		{"table", true, "total", "2\n"},
+		{"table", true, "content[0]", "{\n  \"id\": \"1\",\n  \"name\": \"a.txt\",\n  \"size\": 10\n}\n"},
+		{"json", false, "content[].size", "[\n  10,\n  2048\n]\n"},
+		{"ndjson", false, "content[].{n: name}", "{\"n\":\"a.txt\"}\n{\"n\":\"b,c.txt\"}\n"},
+		{"yaml", false, "content[1]", "id: \"2\"\nname: b,c.txt\nsize: 2048\n"},
+		{"template={{.name}}", false, "content", "a.txt\nb,c.txt\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.query, func(t *testing.T) {
+			inner, err := NewFormatter(tt.format)
+			if err != nil {
+				t.Fatalf("NewFormatter() error = %v", err)
+			}
+			f, err := NewQueryFormatter(inner, tt.human, tt.query)
+			if err != nil {
+				t.Fatalf("NewQueryFormatter() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := f.Print(&buf, testResult()); err != nil {
+				t.Fatalf("Print() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryFormatter_Stream(t *testing.T) {
+	inner, _ := NewFormatter(FormatJSON)
+	f, err := NewQueryFormatter(inner, false, "content[?size > `1000`].id")
+	if err != nil {
+		t.Fatalf("NewQueryFormatter() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	stream := f.Stream(&buf, nil)
+	for _, item := range testResult().Items.([]testItem) {
+		if err := stream.Write(item, nil); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output before Close(), got %q", buf.String())
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if want := "[\n  \"2\"\n]\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestNewQueryFormatter_Invalid(t *testing.T) {
+	inner, _ := NewFormatter(FormatJSON)
+	if _, err := NewQueryFormatter(inner, false, "content[?"); err == nil {
+		t.Error("Expected error for invalid query, got nil")
+	}
+}
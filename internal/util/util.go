@@ -15,7 +15,12 @@ limitations under the License.
 */
 package util
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // FormatFileSize formats file size in bytes to human-readable format
 // Examples: 1024 -> "1.0 KB", 1048576 -> "1.0 MB"
@@ -31,3 +36,77 @@ func FormatFileSize(size int64) string {
 	}
 	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
 }
+
+// ParseDuration parses a duration like time.ParseDuration, and also accepts
+// whole days and weeks with the "d" and "w" suffixes
+// Examples: "90d" -> 2160h, "2w" -> 336h, "36h" -> 36h
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("duration cannot be empty")
+	}
+
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(value, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	if unit == 0 {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %s (examples: 90d, 2w, 36h)", value)
+		}
+		return d, nil
+	}
+
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid duration: %s (examples: 90d, 2w, 36h)", value)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// ParseFileSize parses a human-readable size into bytes, using the same
+// 1024-based units as FormatFileSize
+// Examples: "512" -> 512, "10MB" -> 10485760, "1.5 GB" -> 1610612736
+func ParseFileSize(value string) (int64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" {
+		return 0, fmt.Errorf("size cannot be empty")
+	}
+
+	number := strings.TrimRight(value, "KMGTPIB ")
+	unit := strings.TrimSpace(value[len(number):])
+	multipliers := map[string]float64{
+		"": 1, "B": 1,
+		"K": 1 << 10, "KB": 1 << 10, "KIB": 1 << 10,
+		"M": 1 << 20, "MB": 1 << 20, "MIB": 1 << 20,
+		"G": 1 << 30, "GB": 1 << 30, "GIB": 1 << 30,
+		"T": 1 << 40, "TB": 1 << 40, "TIB": 1 << 40,
+	}
+	multiplier, ok := multipliers[unit]
+	n, err := strconv.ParseFloat(number, 64)
+	if !ok || err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size: %s (examples: 512, 10MB, 1.5GB)", value)
+	}
+	return int64(n * multiplier), nil
+}
+
+// ParseTime parses an absolute time (RFC3339 or YYYY-MM-DD) or a duration
+// before now as accepted by ParseDuration
+// Examples: "2025-01-31", "2025-01-31T12:00:00Z", "7d" -> now minus 7 days
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if d, err := ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %s (examples: 2025-01-31, 2025-01-31T12:00:00Z, 7d)", value)
+}